@@ -0,0 +1,93 @@
+// Command tronabigen generates typed Go bindings for TRON smart contracts,
+// from an ABI file or from the ABI stored on chain.
+//
+//	tronabigen -abi token.abi -pkg token -type Token -out token.go
+//	tronabigen -contract TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t -pkg usdt -type USDT
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/abi/bind"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	abiFile := flag.String("abi", "", "path to the contract ABI JSON (solidity or node format), - for stdin")
+	contract := flag.String("contract", "", "base58 address of a deployed contract to fetch the ABI from")
+	node := flag.String("node", "grpc.trongrid.io:50051", "gRPC node used with -contract")
+	apiKey := flag.String("apikey", "", "TronGrid API key used with -contract")
+	pkg := flag.String("pkg", "", "package name of the generated file")
+	typeName := flag.String("type", "", "Go type name of the binding")
+	out := flag.String("out", "", "output file, stdout when empty")
+	flag.Parse()
+
+	if err := run(*abiFile, *contract, *node, *apiKey, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "tronabigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiFile, contract, node, apiKey, pkg, typeName, out string) error {
+	if len(pkg) == 0 || len(typeName) == 0 {
+		return fmt.Errorf("-pkg and -type are required")
+	}
+
+	var contractABI *core.SmartContract_ABI
+	var err error
+	switch {
+	case len(abiFile) > 0 && len(contract) > 0:
+		return fmt.Errorf("use either -abi or -contract")
+	case len(abiFile) > 0:
+		contractABI, err = loadABI(abiFile)
+	case len(contract) > 0:
+		contractABI, err = fetchABI(node, apiKey, contract)
+	default:
+		return fmt.Errorf("one of -abi or -contract is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	code, err := bind.Generate(pkg, typeName, contractABI)
+	if err != nil {
+		return err
+	}
+	if len(out) == 0 {
+		_, err = fmt.Fprint(os.Stdout, code)
+		return err
+	}
+	return os.WriteFile(out, []byte(code), 0644)
+}
+
+func loadABI(file string) (*core.SmartContract_ABI, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return abi.ParseJSON(data)
+}
+
+func fetchABI(node, apiKey, contract string) (*core.SmartContract_ABI, error) {
+	c := client.NewGrpcClient(node)
+	if err := c.SetAPIKey(apiKey); err != nil {
+		return nil, err
+	}
+	if err := c.Start(grpc.WithInsecure()); err != nil {
+		return nil, err
+	}
+	defer c.Stop()
+	return c.GetContractABI(contract)
+}
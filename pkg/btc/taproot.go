@@ -0,0 +1,132 @@
+package btc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"strings"
+
+	btcec2 "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+const bech32mConst = 0x2bc830a3
+
+// AddressTaproot is a BIP-341 pay-to-taproot address, witness version 1
+type AddressTaproot struct {
+	hrp       string
+	outputKey [32]byte
+}
+
+// NewAddressTaproot returns the address of a 32 bytes taproot output key
+func NewAddressTaproot(outputKey []byte, params *chaincfg.Params) (*AddressTaproot, error) {
+	if len(outputKey) != 32 {
+		return nil, errors.New("taproot output key must be 32 bytes")
+	}
+	addr := &AddressTaproot{hrp: params.Bech32HRPSegwit}
+	copy(addr.outputKey[:], outputKey)
+	return addr, nil
+}
+
+// EncodeAddress implements btcutil.Address, a bech32m string
+func (a *AddressTaproot) EncodeAddress() string {
+	program, err := bech32.ConvertBits(a.outputKey[:], 8, 5, true)
+	if err != nil {
+		return ""
+	}
+	return encodeBech32m(a.hrp, append([]byte{1}, program...))
+}
+
+// ScriptAddress implements btcutil.Address, the output key
+func (a *AddressTaproot) ScriptAddress() []byte {
+	return a.outputKey[:]
+}
+
+// IsForNet implements btcutil.Address
+func (a *AddressTaproot) IsForNet(params *chaincfg.Params) bool {
+	return a.hrp == params.Bech32HRPSegwit
+}
+
+// String implements btcutil.Address
+func (a *AddressTaproot) String() string {
+	return a.EncodeAddress()
+}
+
+// TaprootOutputKey tweaks the internal key without script path, as BIP-86
+func TaprootOutputKey(compressedPublicKey []byte) ([]byte, error) {
+	if len(compressedPublicKey) != 33 {
+		return nil, errors.New("public key must be compressed")
+	}
+	xOnly := compressedPublicKey[1:]
+	// lift_x, the point with the even y
+	internal, err := btcec2.ParsePubKey(append([]byte{0x02}, xOnly...))
+	if err != nil {
+		return nil, err
+	}
+
+	var tweak btcec2.ModNScalar
+	if overflow := tweak.SetByteSlice(taggedHash("TapTweak", xOnly)); overflow {
+		return nil, errors.New("taproot tweak out of range")
+	}
+	var p, t, q btcec2.JacobianPoint
+	internal.AsJacobian(&p)
+	btcec2.ScalarBaseMultNonConst(&tweak, &t)
+	btcec2.AddNonConst(&p, &t, &q)
+	q.ToAffine()
+	outputKey := q.X.Bytes()
+	return outputKey[:], nil
+}
+
+func taggedHash(tag string, msg []byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// encodeBech32m encodes 5 bits data with the BIP-350 checksum
+func encodeBech32m(hrp string, data []byte) string {
+	hrp = strings.ToLower(hrp)
+	values := make([]byte, 0, len(hrp)*2+1+len(data)+6)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	values = append(values, data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ bech32mConst
+
+	var b bytes.Buffer
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, d := range data {
+		b.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return b.String()
+}
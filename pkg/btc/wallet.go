@@ -261,8 +261,11 @@ func PublicKeyToLegacyAddress(publicKey *btcec.PublicKey, params *chaincfg.Param
 	return btcutil.NewAddressPubKeyHash(pubKeyHash, params)
 }
 
-// PublicKeyToTaprootAddress 生成 Taproot 地址
+// PublicKeyToTaprootAddress 生成 Taproot 地址 (BIP-86, 无脚本路径)
 func PublicKeyToTaprootAddress(publicKey *btcec.PublicKey, params *chaincfg.Params) (btcutil.Address, error) {
-	pubKeyHash := btcutil.Hash160(publicKey.SerializeCompressed())
-	return btcutil.NewAddressWitnessScriptHash(pubKeyHash, params)
+	outputKey, err := TaprootOutputKey(publicKey.SerializeCompressed())
+	if err != nil {
+		return nil, err
+	}
+	return NewAddressTaproot(outputKey, params)
 }
@@ -0,0 +1,69 @@
+package btc
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// SLIP-132 extended public key versions
+var (
+	XPubVersion = []byte{0x04, 0x88, 0xb2, 0x1e} // BIP-44 and BIP-86
+	YPubVersion = []byte{0x04, 0x9d, 0x7c, 0xb2} // BIP-49, nested segwit
+	ZPubVersion = []byte{0x04, 0xb2, 0x47, 0x46} // BIP-84, native segwit
+	TPubVersion = []byte{0x04, 0x35, 0x87, 0xcf} // testnet xpub
+	UPubVersion = []byte{0x04, 0x4a, 0x52, 0x62} // testnet ypub
+	VPubVersion = []byte{0x04, 0x5f, 0x1c, 0x3f} // testnet zpub
+)
+
+// ExtendedPublicKeyVersion returns the version of the account extended
+// public keys of purpose: ypub for 49, zpub for 84 and xpub otherwise
+func ExtendedPublicKeyVersion(purpose uint32, params *chaincfg.Params) []byte {
+	testnet := params != nil && params.HDPublicKeyID != chaincfg.MainNetParams.HDPublicKeyID
+	switch {
+	case purpose == 49 && testnet:
+		return UPubVersion
+	case purpose == 49:
+		return YPubVersion
+	case purpose == 84 && testnet:
+		return VPubVersion
+	case purpose == 84:
+		return ZPubVersion
+	case testnet:
+		return TPubVersion
+	default:
+		return XPubVersion
+	}
+}
+
+// AccountExtendedPublicKey returns the extended public key of the account
+// m/purpose'/0'/account' of the mnemonic, in the SLIP-132 version of purpose.
+// The coin type is 0 on every network, as for the Generate*AddressFromMnemonic
+// functions, only the version follows params.
+func AccountExtendedPublicKey(mnemonic string, purpose, account uint32, params *chaincfg.Params) (string, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return "", err
+	}
+	key, err := hdkeychain.NewMaster(seed, params)
+	if err != nil {
+		return "", err
+	}
+	for _, n := range []uint32{purpose, 0, account} {
+		key, err = key.Derive(hdkeychain.HardenedKeyStart + n)
+		if err != nil {
+			return "", err
+		}
+	}
+	public, err := key.Neuter()
+	if err != nil {
+		return "", err
+	}
+	public, err = public.CloneWithVersion(ExtendedPublicKeyVersion(purpose, params))
+	if err != nil {
+		return "", fmt.Errorf("extended public key version: %w", err)
+	}
+	return public.String(), nil
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tyler-smith/go-bip39"
+
+	"github.com/EntySquare/chain-util/pkg/secret"
 )
 
 // 指定派生路径
@@ -65,6 +67,26 @@ func AddressFromPrivateKey(privateKeyHex string) (*ecdsa.PublicKey, error) {
 	return &publicKey, nil
 }
 
+// AddressFromPrivateKeySecret 从私钥生成公钥
+func AddressFromPrivateKeySecret(privateKey secret.PrivateKey) (*ecdsa.PublicKey, error) {
+	return privateKey.PublicKey()
+}
+
+// GetMnemonicSecret 生成助记词，助记词不会被打印或序列化
+func GetMnemonicSecret(bitSize int) (secret.Mnemonic, error) {
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return secret.Mnemonic{}, err
+	}
+	defer secret.Zero(entropy)
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return secret.Mnemonic{}, err
+	}
+	return secret.NewMnemonic(mnemonic), nil
+}
+
 // GetMnemonicBy256  生成助记词
 func GetMnemonicBy256() (string, error) {
 	mnemonic, err := GetMnemonic(256)
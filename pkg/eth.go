@@ -1,8 +1,8 @@
 package pkg
 
 import (
+	"github.com/EntySquare/chain-util/pkg/secret"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/tyler-smith/go-bip39"
 	"regexp"
 )
 
@@ -17,30 +17,53 @@ func EthAddressFromPrivateKey(privateKeyHex string) (string, error) {
 	return address.Hex(), nil
 }
 
+// EthAddressFromSecret 从私钥生成地址
+func EthAddressFromSecret(privateKey secret.PrivateKey) (string, error) {
+	publicKey, err := AddressFromPrivateKeySecret(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(*publicKey).Hex(), nil
+}
+
 // EthGenerateAddressFromMnemonic Eth 根据助记词生成地址和私钥
 func EthGenerateAddressFromMnemonic(mnemonic string) (address string, privateKey string, err error) {
+	m := secret.NewMnemonic(mnemonic)
+	defer m.Destroy()
+	address, key, err := EthGenerateAddressFromMnemonicSecret(m)
+	if err != nil {
+		return "", "", err
+	}
+	defer key.Destroy()
+	return address, key.RevealHex(), nil
+}
+
+// EthGenerateAddressFromMnemonicSecret Eth 根据助记词生成地址和私钥，私钥不会被打印或序列化
+func EthGenerateAddressFromMnemonicSecret(mnemonic secret.Mnemonic) (string, secret.PrivateKey, error) {
 	// 生成私钥  这里可以选择传入指定密码或者空字符串，不同密码生成的助记词不同
-	seed := bip39.NewSeed(mnemonic, "")
+	seed, err := mnemonic.Seed("")
+	if err != nil {
+		return "", secret.PrivateKey{}, err
+	}
+	defer secret.Zero(seed)
 	// 使用种子生成 BIP32 主密钥
 	wallet, err := EthNewFromSeed(seed)
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
 	path, err := MustParseDerivationPath(EthDerivationPath) //最后一位是同一个助记词的地址id，从0开始，相同助记词可以生产无限个地址
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
 	account, err := wallet.Derive(path, false)
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
-	address = account.Address.Hex()
-	privateKey, err = wallet.PrivateKeyHex(account)
+	privateKey, err := wallet.PrivateKeySecret(account)
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
-
-	return address, privateKey, nil
+	return account.Address.Hex(), privateKey, nil
 }
 
 // EthCreateWallet ETH   创建钱包 生成地址和私钥 助记词
@@ -59,6 +82,20 @@ func EthCreateWallet() (address string, privateKey string, mnemonic string, err
 	return address, privateKey, mnemonic, nil
 }
 
+// EthCreateWalletSecret ETH 创建钱包 生成地址和私钥 助记词，私钥和助记词不会被打印或序列化
+func EthCreateWalletSecret() (string, secret.PrivateKey, secret.Mnemonic, error) {
+	mnemonic, err := GetMnemonicSecret(256)
+	if err != nil {
+		return "", secret.PrivateKey{}, secret.Mnemonic{}, err
+	}
+	address, privateKey, err := EthGenerateAddressFromMnemonicSecret(mnemonic)
+	if err != nil {
+		mnemonic.Destroy()
+		return "", secret.PrivateKey{}, secret.Mnemonic{}, err
+	}
+	return address, privateKey, mnemonic, nil
+}
+
 func IsValidEthAddress(address string) bool {
 	// 定义 BSC 地址的正则表达式
 	bscAddressPattern := regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
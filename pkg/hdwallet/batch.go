@@ -0,0 +1,280 @@
+package hdwallet
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// DefaultGapLimit is the BIP-44 address gap limit
+const DefaultGapLimit = 20
+
+// Batch derives many addresses of one account. The account and change nodes
+// are derived once and cached, so each address costs two child derivations
+// instead of a derivation from the master key.
+type Batch struct {
+	// Workers is the number of concurrent derivations, runtime.NumCPU() when 0
+	Workers int
+
+	chain   Chain
+	path    accounts.DerivationPath
+	account *hdkeychain.ExtendedKey
+	legacy  bool
+
+	mu      sync.Mutex
+	changes map[uint32]*hdkeychain.ExtendedKey
+}
+
+// Result is one address of a range, Index being its address index
+type Result struct {
+	Index   uint32
+	Account *Account
+	Err     error
+}
+
+// UsageChecker tells whether an address was used, by its balance or history
+type UsageChecker interface {
+	Used(ctx context.Context, account *Account) (bool, error)
+}
+
+// UsageCheckerFunc adapts a function to UsageChecker
+type UsageCheckerFunc func(ctx context.Context, account *Account) (bool, error)
+
+// Used implements UsageChecker
+func (f UsageCheckerFunc) Used(ctx context.Context, account *Account) (bool, error) {
+	return f(ctx, account)
+}
+
+// Batch returns a deriver of m/purpose'/coin'/account', with private keys.
+// For addresses only, use the Batch of a WatchOnly of AccountXPub.
+func (w *Wallet) Batch(chain Chain, account uint32) (*Batch, error) {
+	if err := chain.Validate(); err != nil {
+		return nil, err
+	}
+	path := chain.AccountPath(account)
+	key, err := derive(w.master, path, w.legacy)
+	if err != nil {
+		return nil, err
+	}
+	return newBatch(chain, path, key, w.legacy)
+}
+
+// Batch returns a deriver of the addresses of the extended public key
+func (w *WatchOnly) Batch() (*Batch, error) {
+	key, err := hdkeychain.NewKeyFromString(w.key.String())
+	if err != nil {
+		return nil, err
+	}
+	// public derivation is the same in the legacy way
+	return newBatch(w.chain, w.path, key, false)
+}
+
+func newBatch(chain Chain, path accounts.DerivationPath, key *hdkeychain.ExtendedKey, legacy bool) (*Batch, error) {
+	// the public key is computed lazily, do it before sharing the node
+	if _, err := key.ECPubKey(); err != nil {
+		return nil, err
+	}
+	return &Batch{
+		chain:   chain,
+		path:    path,
+		account: key,
+		legacy:  legacy,
+		changes: make(map[uint32]*hdkeychain.ExtendedKey),
+	}, nil
+}
+
+// Chain returns the chain the addresses are encoded for
+func (b *Batch) Chain() Chain {
+	return b.chain
+}
+
+// Derive returns the account change/index, it is safe for concurrent use
+func (b *Batch) Derive(change, index uint32) (*Account, error) {
+	if index >= hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("address index %d is hardened", index)
+	}
+	changeKey, err := b.changeKey(change)
+	if err != nil {
+		return nil, err
+	}
+	key, err := deriveChild(changeKey, index, b.legacy)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Zero()
+	path := append(append(accounts.DerivationPath{}, b.path...), change, index)
+	return newAccount(b.chain, path, key)
+}
+
+func (b *Batch) changeKey(change uint32) (*hdkeychain.ExtendedKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if key, ok := b.changes[change]; ok {
+		return key, nil
+	}
+	if change >= hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("change %d is hardened", change)
+	}
+	key, err := deriveChild(b.account, change, b.legacy)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := key.ECPubKey(); err != nil {
+		return nil, err
+	}
+	b.changes[change] = key
+	return key, nil
+}
+
+// Range derives count addresses of change from index from concurrently. The
+// results are streamed out of order and the channel is closed once done.
+// Cancel ctx to stop early when not reading every result.
+func (b *Batch) Range(ctx context.Context, change, from, count uint32) <-chan Result {
+	indexes := make(chan uint32)
+	go func() {
+		defer close(indexes)
+		for i := uint32(0); i < count; i++ {
+			select {
+			case indexes <- from + i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out := make(chan Result, b.workers())
+	var wg sync.WaitGroup
+	for n := 0; n < b.workers(); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				account, err := b.Derive(change, index)
+				select {
+				case out <- Result{Index: index, Account: account, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Discover finds the used addresses of change, checking ahead until gapLimit
+// consecutive addresses are unused, DefaultGapLimit when 0. The accounts are
+// sorted by index.
+func (b *Batch) Discover(ctx context.Context, change, gapLimit uint32, checker UsageChecker) ([]*Account, error) {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		used []*Account
+		// next is the first index past every used one
+		next    uint32
+		scanned uint32
+	)
+	for scanned < next+gapLimit {
+		window := next + gapLimit - scanned
+		found, err := b.checkRange(ctx, change, scanned, window, checker)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range found {
+			used = append(used, account)
+			if index := account.Path[len(account.Path)-1]; index >= next {
+				next = index + 1
+			}
+		}
+		scanned += window
+	}
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].Path[len(used[i].Path)-1] < used[j].Path[len(used[j].Path)-1]
+	})
+	return used, nil
+}
+
+// checkRange returns the used accounts of a range
+func (b *Batch) checkRange(ctx context.Context, change, from, count uint32, checker UsageChecker) ([]*Account, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := b.Range(ctx, change, from, count)
+	type check struct {
+		account *Account
+		used    bool
+		err     error
+	}
+	checks := make(chan check)
+	var wg sync.WaitGroup
+	for n := 0; n < b.workers(); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for result := range results {
+				c := check{account: result.Account, err: result.Err}
+				if c.err == nil {
+					c.used, c.err = checker.Used(ctx, result.Account)
+					if c.err != nil {
+						c.err = fmt.Errorf("check %s: %w", result.Account.Address, c.err)
+					}
+				}
+				select {
+				case checks <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(checks)
+	}()
+
+	var used []*Account
+	for c := range checks {
+		if c.err != nil {
+			return nil, c.err
+		}
+		if c.used {
+			used = append(used, c.account)
+		} else {
+			c.account.PrivateKey.Destroy()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return used, nil
+}
+
+// Destroy zeroes the cached nodes, the batch cannot derive afterwards
+func (b *Batch) Destroy() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for change, key := range b.changes {
+		key.Zero()
+		delete(b.changes, change)
+	}
+	b.account.Zero()
+}
+
+func (b *Batch) workers() int {
+	if b.Workers > 0 {
+		return b.Workers
+	}
+	return runtime.NumCPU()
+}
@@ -0,0 +1,141 @@
+package hdwallet_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/hdwallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchRange(t *testing.T) {
+	w, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(t, err)
+	batch, err := w.Batch(hdwallet.TRON, 2)
+	require.Nil(t, err)
+	batch.Workers = 4
+
+	addresses := make(map[uint32]string)
+	for result := range batch.Range(context.Background(), 0, 100, 300) {
+		require.Nil(t, result.Err)
+		addresses[result.Index] = result.Account.Address
+	}
+	require.Len(t, addresses, 300)
+	for _, index := range []uint32{100, 101, 250, 399} {
+		expected, err := w.Derive(hdwallet.TRON, 2, 0, index)
+		require.Nil(t, err)
+		assert.Equal(t, expected.Address, addresses[index])
+	}
+
+	account, err := batch.Derive(1, 7)
+	require.Nil(t, err)
+	expected, err := w.Derive(hdwallet.TRON, 2, 1, 7)
+	require.Nil(t, err)
+	assert.Equal(t, expected.Path, account.Path)
+	assert.Equal(t, expected.PrivateKey.RevealHex(), account.PrivateKey.RevealHex())
+
+	// watch-only batches derive the same addresses
+	xpub, err := w.AccountXPub(hdwallet.TRON, 2)
+	require.Nil(t, err)
+	watch, err := hdwallet.NewWatchOnly(hdwallet.TRON, xpub)
+	require.Nil(t, err)
+	watchBatch, err := watch.Batch()
+	require.Nil(t, err)
+	for result := range watchBatch.Range(context.Background(), 0, 100, 300) {
+		require.Nil(t, result.Err)
+		assert.Equal(t, addresses[result.Index], result.Account.Address)
+		assert.True(t, result.Account.PrivateKey.Destroyed())
+	}
+	watchBatch.Destroy()
+	_, err = watch.Derive(0, 0)
+	assert.Nil(t, err)
+
+	// stopping early
+	ctx, cancel := context.WithCancel(context.Background())
+	results := batch.Range(ctx, 0, 0, 100000)
+	<-results
+	cancel()
+	for range results {
+	}
+}
+
+type usedSet struct {
+	mu      sync.Mutex
+	used    map[string]bool
+	checked int
+}
+
+func (u *usedSet) Used(_ context.Context, account *hdwallet.Account) (bool, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.checked++
+	return u.used[account.Address], nil
+}
+
+func TestDiscover(t *testing.T) {
+	w, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(t, err)
+	batch, err := w.Batch(hdwallet.BTCNativeSegwit, 0)
+	require.Nil(t, err)
+
+	usedAt := func(indexes ...uint32) *usedSet {
+		u := &usedSet{used: make(map[string]bool)}
+		for _, index := range indexes {
+			account, err := batch.Derive(0, index)
+			require.Nil(t, err)
+			u.used[account.Address] = true
+		}
+		return u
+	}
+	indexes := func(accounts []*hdwallet.Account) []uint32 {
+		var out []uint32
+		for _, account := range accounts {
+			out = append(out, account.Path[len(account.Path)-1])
+		}
+		return out
+	}
+
+	checker := usedAt(0, 3, 20, 39)
+	found, err := batch.Discover(context.Background(), 0, 0, checker)
+	require.Nil(t, err)
+	assert.Equal(t, []uint32{0, 3, 20, 39}, indexes(found))
+	assert.Equal(t, 60, checker.checked)
+
+	// 25 is past the gap after 3
+	found, err = batch.Discover(context.Background(), 0, 0, usedAt(0, 3, 25))
+	require.Nil(t, err)
+	assert.Equal(t, []uint32{0, 3}, indexes(found))
+
+	checker = usedAt()
+	found, err = batch.Discover(context.Background(), 0, 5, checker)
+	require.Nil(t, err)
+	assert.Empty(t, found)
+	assert.Equal(t, 5, checker.checked)
+
+	failing := errors.New("node unavailable")
+	_, err = batch.Discover(context.Background(), 0, 0, hdwallet.UsageCheckerFunc(func(context.Context, *hdwallet.Account) (bool, error) {
+		return false, failing
+	}))
+	assert.True(t, errors.Is(err, failing))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = batch.Discover(ctx, 0, 0, usedAt(0))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkBatchRange(b *testing.B) {
+	w, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(b, err)
+	batch, err := w.Batch(hdwallet.TRON, 0)
+	require.Nil(b, err)
+	b.ResetTimer()
+	for result := range batch.Range(context.Background(), 0, 0, uint32(b.N)) {
+		if result.Err != nil {
+			b.Fatal(result.Err)
+		}
+	}
+}
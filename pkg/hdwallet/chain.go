@@ -0,0 +1,190 @@
+package hdwallet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/EntySquare/chain-util/pkg/btc"
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AddressType is how a chain encodes a public key as an address
+type AddressType int
+
+const (
+	// AddressEthereum is the EIP-55 hex of the keccak hash, ETH, BSC and other EVM chains
+	AddressEthereum AddressType = iota
+	// AddressTron is the base58check of the 0x41 prefixed keccak hash
+	AddressTron
+	// AddressP2PKH is a legacy bitcoin address
+	AddressP2PKH
+	// AddressP2SHP2WPKH is a nested segwit bitcoin address
+	AddressP2SHP2WPKH
+	// AddressP2WPKH is a native segwit bitcoin address
+	AddressP2WPKH
+	// AddressP2TR is a BIP-86 taproot bitcoin address
+	AddressP2TR
+)
+
+// String returns the name of the address type
+func (t AddressType) String() string {
+	switch t {
+	case AddressEthereum:
+		return "ethereum"
+	case AddressTron:
+		return "tron"
+	case AddressP2PKH:
+		return "p2pkh"
+	case AddressP2SHP2WPKH:
+		return "p2sh-p2wpkh"
+	case AddressP2WPKH:
+		return "p2wpkh"
+	case AddressP2TR:
+		return "p2tr"
+	default:
+		return fmt.Sprintf("AddressType(%d)", int(t))
+	}
+}
+
+// bitcoin returns true for the address types needing Chain.Params
+func (t AddressType) bitcoin() bool {
+	return t >= AddressP2PKH && t <= AddressP2TR
+}
+
+// Chain describes how to derive the keys and addresses of a coin, the path
+// of a key being m/Purpose'/CoinType'/account'/change/index
+type Chain struct {
+	Name string
+	// Purpose is the BIP-43 purpose, 44, 49, 84 or 86
+	Purpose uint32
+	// CoinType is the SLIP-44 coin type
+	CoinType uint32
+	Address  AddressType
+	// Params are the network of bitcoin like chains, address versions and WIF
+	Params *chaincfg.Params
+}
+
+// Chains supported out of the box
+var (
+	TRON            = Chain{Name: "tron", Purpose: 44, CoinType: 195, Address: AddressTron}
+	ETH             = Chain{Name: "eth", Purpose: 44, CoinType: 60, Address: AddressEthereum}
+	BSC             = Chain{Name: "bsc", Purpose: 44, CoinType: 60, Address: AddressEthereum}
+	BTCLegacy       = Chain{Name: "btc-legacy", Purpose: 44, CoinType: 0, Address: AddressP2PKH, Params: &chaincfg.MainNetParams}
+	BTCNestedSegwit = Chain{Name: "btc-nested-segwit", Purpose: 49, CoinType: 0, Address: AddressP2SHP2WPKH, Params: &chaincfg.MainNetParams}
+	BTCNativeSegwit = Chain{Name: "btc-native-segwit", Purpose: 84, CoinType: 0, Address: AddressP2WPKH, Params: &chaincfg.MainNetParams}
+	BTCTaproot      = Chain{Name: "btc-taproot", Purpose: 86, CoinType: 0, Address: AddressP2TR, Params: &chaincfg.MainNetParams}
+)
+
+var (
+	chainsMu sync.RWMutex
+	chains   = make(map[string]Chain)
+)
+
+func init() {
+	for _, c := range []Chain{TRON, ETH, BSC, BTCLegacy, BTCNestedSegwit, BTCNativeSegwit, BTCTaproot} {
+		chains[c.Name] = c
+	}
+}
+
+// Validate checks the chain can derive addresses
+func (c Chain) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("chain has no name")
+	}
+	if c.Address < AddressEthereum || c.Address > AddressP2TR {
+		return fmt.Errorf("chain %s: unknown address type %d", c.Name, int(c.Address))
+	}
+	if c.Address.bitcoin() && c.Params == nil {
+		return fmt.Errorf("chain %s: %s addresses need network params", c.Name, c.Address)
+	}
+	return nil
+}
+
+// Register adds or replaces a chain by name, so it can be looked up with ChainByName
+func Register(c Chain) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	chainsMu.Lock()
+	defer chainsMu.Unlock()
+	chains[strings.ToLower(c.Name)] = c
+	return nil
+}
+
+// ChainByName returns a registered chain
+func ChainByName(name string) (Chain, error) {
+	chainsMu.RLock()
+	defer chainsMu.RUnlock()
+	c, ok := chains[strings.ToLower(name)]
+	if !ok {
+		return Chain{}, fmt.Errorf("unknown chain %s", name)
+	}
+	return c, nil
+}
+
+// Chains returns the registered chain names, sorted
+func Chains() []string {
+	chainsMu.RLock()
+	defer chainsMu.RUnlock()
+	names := make([]string, 0, len(chains))
+	for name := range chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AccountPath returns m/purpose'/coin'/account'
+func (c Chain) AccountPath(account uint32) accounts.DerivationPath {
+	return accounts.DerivationPath{
+		hardened(c.Purpose),
+		hardened(c.CoinType),
+		hardened(account),
+	}
+}
+
+// Path returns m/purpose'/coin'/account'/change/index
+func (c Chain) Path(account, change, index uint32) accounts.DerivationPath {
+	return append(c.AccountPath(account), change, index)
+}
+
+// EncodeAddress returns the address of a public key
+func (c Chain) EncodeAddress(publicKey *btcec.PublicKey) (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	var (
+		address btcutil.Address
+		err     error
+	)
+	switch c.Address {
+	case AddressEthereum:
+		return crypto.PubkeyToAddress(*publicKey.ToECDSA()).Hex(), nil
+	case AddressTron:
+		return tron.PublicKeyToAddress(*publicKey.ToECDSA()).String(), nil
+	case AddressP2PKH:
+		address, err = btc.PublicKeyToLegacyAddress(publicKey, c.Params)
+	case AddressP2SHP2WPKH:
+		address, err = btc.PublicKeyToP2SHAddress(publicKey, c.Params)
+	case AddressP2WPKH:
+		address, err = btc.PublicKeyToP2WPKHAddress(publicKey, c.Params)
+	case AddressP2TR:
+		address, err = btc.PublicKeyToTaprootAddress(publicKey, c.Params)
+	}
+	if err != nil {
+		return "", err
+	}
+	return address.EncodeAddress(), nil
+}
+
+func hardened(n uint32) uint32 {
+	return n + hdkeychain.HardenedKeyStart
+}
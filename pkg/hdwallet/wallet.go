@@ -0,0 +1,201 @@
+// Package hdwallet derives the keys and addresses of several chains from one
+// BIP-39 mnemonic. A chain is a Chain descriptor, supporting a new coin is a
+// new descriptor rather than new code.
+//
+// Keys follow standard BIP-32. The older APIs (pkg.Wallet without the issue
+// 172 fix, tron.GenerateAddressFromMnemonic, pkg.EthGenerateAddressFromMnemonic)
+// derive some keys differently, use the NewLegacy constructors to find the
+// addresses they created.
+package hdwallet
+
+import (
+	"fmt"
+
+	"github.com/EntySquare/chain-util/pkg/secret"
+	"github.com/EntySquare/chain-util/pkg/slip39"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Wallet is the BIP-32 master key of a seed
+type Wallet struct {
+	master *hdkeychain.ExtendedKey
+	// legacy derivation drops the leading zeros of private keys, btcutil
+	// issue 172, as the older APIs of this module do
+	legacy bool
+}
+
+// Account is a derived key
+type Account struct {
+	Chain   Chain
+	Path    accounts.DerivationPath
+	Address string
+	// PublicKey is compressed
+	PublicKey []byte
+	// PrivateKey is empty for watch-only accounts
+	PrivateKey secret.PrivateKey
+}
+
+// NewFromMnemonic checks the mnemonic and returns its wallet
+func NewFromMnemonic(mnemonic, passphrase string) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Zero(seed)
+	return NewFromSeed(seed)
+}
+
+// NewFromSecret checks the mnemonic and returns its wallet
+func NewFromSecret(mnemonic secret.Mnemonic, passphrase string) (*Wallet, error) {
+	if !mnemonic.Valid() {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+	seed, err := mnemonic.Seed(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Zero(seed)
+	return NewFromSeed(seed)
+}
+
+// NewFromSeed returns the wallet of a BIP-39 seed
+func NewFromSeed(seed []byte) (*Wallet, error) {
+	return newFromSeed(seed, false)
+}
+
+// NewFromShares combines SLIP-39 share mnemonics and returns the wallet of
+// their master secret, see slip39.SplitMnemonic
+func NewFromShares(shares []string, passphrase string) (*Wallet, error) {
+	seed, err := slip39.Combine(shares, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Zero(seed)
+	return NewFromSeed(seed)
+}
+
+// NewLegacyFromMnemonic checks the mnemonic and returns its wallet with the
+// non-standard derivation of pkg.Wallet, tron.GenerateAddressFromMnemonic and
+// pkg.EthGenerateAddressFromMnemonic
+func NewLegacyFromMnemonic(mnemonic, passphrase string) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Zero(seed)
+	return NewLegacyFromSeed(seed)
+}
+
+// NewLegacyFromSeed returns the wallet of a BIP-39 seed with the non-standard
+// derivation of pkg.Wallet, see NewLegacyFromMnemonic
+func NewLegacyFromSeed(seed []byte) (*Wallet, error) {
+	return newFromSeed(seed, true)
+}
+
+func newFromSeed(seed []byte, legacy bool) (*Wallet, error) {
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{master: master, legacy: legacy}, nil
+}
+
+// Legacy returns true for the non-standard derivation of the older APIs
+func (w *Wallet) Legacy() bool {
+	return w.legacy
+}
+
+// Derive returns the account at m/purpose'/coin'/account'/change/index of chain
+func (w *Wallet) Derive(chain Chain, account, change, index uint32) (*Account, error) {
+	return w.DerivePath(chain, chain.Path(account, change, index))
+}
+
+// DerivePath returns the account at any path, encoding the address as chain does
+func (w *Wallet) DerivePath(chain Chain, path accounts.DerivationPath) (*Account, error) {
+	key, err := derive(w.master, path, w.legacy)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Zero()
+	return newAccount(chain, path, key)
+}
+
+// Destroy zeroes the master key, the wallet cannot derive afterwards
+func (w *Wallet) Destroy() {
+	w.master.Zero()
+}
+
+// WIF returns the private key in wallet import format, for bitcoin chains
+func (a *Account) WIF() (string, error) {
+	if !a.Chain.Address.bitcoin() || a.Chain.Params == nil {
+		return "", fmt.Errorf("chain %s has no WIF", a.Chain.Name)
+	}
+	if a.PrivateKey.Destroyed() {
+		return "", fmt.Errorf("account %s has no private key", a.Address)
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), a.PrivateKey.Reveal())
+	defer secret.ZeroECDSA(privateKey.ToECDSA())
+	wif, err := btcutil.NewWIF(privateKey, a.Chain.Params, true)
+	if err != nil {
+		return "", err
+	}
+	return wif.String(), nil
+}
+
+// derive derives the key at path, the legacy way or the standard one
+func derive(key *hdkeychain.ExtendedKey, path accounts.DerivationPath, legacy bool) (*hdkeychain.ExtendedKey, error) {
+	for i, n := range path {
+		child, err := deriveChild(key, n, legacy)
+		if i > 0 {
+			key.Zero()
+		}
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+	if len(path) == 0 {
+		return hdkeychain.NewKeyFromString(key.String())
+	}
+	return key, nil
+}
+
+// deriveChild derives child n, the legacy way drops the leading zeros of
+// private keys as hdkeychain did before the issue 172 fix
+func deriveChild(key *hdkeychain.ExtendedKey, n uint32, legacy bool) (*hdkeychain.ExtendedKey, error) {
+	if legacy {
+		return key.DeriveNonStandard(n)
+	}
+	return key.Derive(n)
+}
+
+func newAccount(chain Chain, path accounts.DerivationPath, key *hdkeychain.ExtendedKey) (*Account, error) {
+	publicKey, err := key.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	address, err := chain.EncodeAddress(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	account := &Account{
+		Chain:     chain,
+		Path:      append(accounts.DerivationPath{}, path...),
+		Address:   address,
+		PublicKey: publicKey.SerializeCompressed(),
+	}
+	if key.IsPrivate() {
+		privateKey, err := key.ECPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		account.PrivateKey = secret.PrivateKeyFromECDSA(privateKey.ToECDSA())
+		secret.ZeroECDSA(privateKey.ToECDSA())
+	}
+	return account, nil
+}
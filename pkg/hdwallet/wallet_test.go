@@ -0,0 +1,165 @@
+package hdwallet_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/hdwallet"
+	"github.com/EntySquare/chain-util/pkg/secret"
+	"github.com/EntySquare/chain-util/pkg/slip39"
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/keys"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// BIP-44/49/84/86 test vectors
+func TestDeriveVectors(t *testing.T) {
+	w, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(t, err)
+
+	vectors := []struct {
+		chain                  hdwallet.Chain
+		account, change, index uint32
+		path, address          string
+	}{
+		{hdwallet.ETH, 0, 0, 0, "m/44'/60'/0'/0/0", "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{hdwallet.BTCLegacy, 0, 0, 0, "m/44'/0'/0'/0/0", "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{hdwallet.BTCNestedSegwit, 0, 0, 0, "m/49'/0'/0'/0/0", "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{hdwallet.BTCNativeSegwit, 0, 0, 0, "m/84'/0'/0'/0/0", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{hdwallet.BTCNativeSegwit, 0, 0, 1, "m/84'/0'/0'/0/1", "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		{hdwallet.BTCNativeSegwit, 0, 1, 0, "m/84'/0'/0'/1/0", "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"},
+		{hdwallet.BTCTaproot, 0, 0, 0, "m/86'/0'/0'/0/0", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{hdwallet.BTCTaproot, 0, 0, 1, "m/86'/0'/0'/0/1", "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh"},
+		{hdwallet.BTCTaproot, 0, 1, 0, "m/86'/0'/0'/1/0", "bc1p3qkhfews2uk44qtvauqyr2ttdsw7svhkl9nkm9s9c3x4ax5h60wqwruhk7"},
+	}
+	for _, v := range vectors {
+		account, err := w.Derive(v.chain, v.account, v.change, v.index)
+		require.Nil(t, err)
+		assert.Equal(t, v.path, account.Path.String())
+		assert.Equal(t, v.address, account.Address, v.path)
+	}
+
+	account, err := w.Derive(hdwallet.BTCNativeSegwit, 0, 0, 0)
+	require.Nil(t, err)
+	wif, err := account.WIF()
+	require.Nil(t, err)
+	assert.Equal(t, "KyZpNDKnfs94vbrwhJneDi77V6jF64PWPF8x5cdJb8ifgg2DUc9d", wif)
+	assert.Equal(t, "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c", hex.EncodeToString(account.PublicKey))
+
+	account, err = w.Derive(hdwallet.ETH, 0, 0, 0)
+	require.Nil(t, err)
+	_, err = account.WIF()
+	assert.NotNil(t, err)
+}
+
+func TestDeriveTron(t *testing.T) {
+	w, err := hdwallet.NewFromSecret(secret.NewMnemonic(testMnemonic), "")
+	require.Nil(t, err)
+
+	address, privateKey, err := tron.GenerateAddressFromMnemonic(testMnemonic)
+	require.Nil(t, err)
+	account, err := w.Derive(hdwallet.TRON, 0, 0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, address, account.Address)
+	assert.Equal(t, privateKey, account.PrivateKey.RevealHex())
+
+	for _, v := range [][2]uint32{{0, 7}, {2, 0}, {3, 11}} {
+		key, err := keys.FromMnemonicAccountIndex(testMnemonic, "", v[0], v[1])
+		require.Nil(t, err)
+		account, err := w.Derive(hdwallet.TRON, v[0], 0, v[1])
+		require.Nil(t, err)
+		assert.Equal(t, hex.EncodeToString(key.Serialize()), account.PrivateKey.RevealHex())
+		assert.Equal(t, tron.PublicKeyToAddress(*key.PubKey().ToECDSA()).String(), account.Address)
+	}
+}
+
+// a mnemonic whose TRON key is affected by btcutil issue 172
+func TestDeriveLegacy(t *testing.T) {
+	const mnemonic = "useless jealous custom elevator avoid price army year fetch struggle pause raccoon"
+
+	w, err := hdwallet.NewFromMnemonic(mnemonic, "")
+	require.Nil(t, err)
+	assert.False(t, w.Legacy())
+	account, err := w.Derive(hdwallet.TRON, 0, 0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, "TTPp3Vb9k9YDKmu5UYqww5Dboq7kYpA6ZS", account.Address)
+
+	legacy, err := hdwallet.NewLegacyFromMnemonic(mnemonic, "")
+	require.Nil(t, err)
+	assert.True(t, legacy.Legacy())
+	account, err = legacy.Derive(hdwallet.TRON, 0, 0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, "TTjv92FiR3jc7Z6zTjdppcSwREJUAARBFT", account.Address)
+
+	address, privateKey, err := tron.GenerateAddressFromMnemonic(mnemonic)
+	require.Nil(t, err)
+	assert.Equal(t, address, account.Address)
+	assert.Equal(t, privateKey, account.PrivateKey.RevealHex())
+
+	batch, err := legacy.Batch(hdwallet.TRON, 0)
+	require.Nil(t, err)
+	batched, err := batch.Derive(0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, account.Address, batched.Address)
+
+	xpub, err := legacy.AccountXPub(hdwallet.TRON, 0)
+	require.Nil(t, err)
+	watch, err := hdwallet.NewWatchOnly(hdwallet.TRON, xpub)
+	require.Nil(t, err)
+	watched, err := watch.Derive(0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, account.Address, watched.Address)
+}
+
+func TestNewFromShares(t *testing.T) {
+	shares, err := slip39.SplitMnemonic(testMnemonic, "", slip39.Options{
+		GroupThreshold: 1,
+		Groups:         []slip39.Group{{Threshold: 2, Count: 3}},
+	})
+	require.Nil(t, err)
+	w, err := hdwallet.NewFromShares([]string{shares[0][0], shares[0][2]}, "")
+	require.Nil(t, err)
+	expected, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(t, err)
+	a, err := w.Derive(hdwallet.TRON, 0, 0, 0)
+	require.Nil(t, err)
+	b, err := expected.Derive(hdwallet.TRON, 0, 0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, b.Address, a.Address)
+
+	_, err = hdwallet.NewFromShares(shares[0][:1], "")
+	assert.NotNil(t, err)
+}
+
+func TestChainDescriptors(t *testing.T) {
+	chain, err := hdwallet.ChainByName("BSC")
+	require.Nil(t, err)
+	assert.Equal(t, hdwallet.BSC, chain)
+	_, err = hdwallet.ChainByName("doge")
+	assert.NotNil(t, err)
+
+	// a new coin is only a descriptor
+	testnet := hdwallet.Chain{Name: "btc-testnet", Purpose: 84, CoinType: 1, Address: hdwallet.AddressP2WPKH, Params: &chaincfg.TestNet3Params}
+	require.Nil(t, hdwallet.Register(testnet))
+	assert.Contains(t, hdwallet.Chains(), "btc-testnet")
+	polygon := hdwallet.Chain{Name: "polygon", Purpose: 44, CoinType: 966, Address: hdwallet.AddressEthereum}
+	require.Nil(t, hdwallet.Register(polygon))
+	assert.NotNil(t, hdwallet.Register(hdwallet.Chain{Name: "broken", Address: hdwallet.AddressP2TR}))
+
+	w, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(t, err)
+	account, err := w.Derive(testnet, 0, 0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, "m/84'/1'/0'/0/0", account.Path.String())
+	assert.Equal(t, "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl", account.Address)
+	account, err = w.Derive(polygon, 1, 0, 2)
+	require.Nil(t, err)
+	assert.Equal(t, "m/44'/966'/1'/0/2", account.Path.String())
+
+	_, err = hdwallet.NewFromMnemonic("abandon abandon", "")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,130 @@
+package hdwallet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/EntySquare/chain-util/pkg/btc"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// ExtendedPublicVersion returns the SLIP-132 version of the account extended
+// public keys of the chain: ypub for nested segwit, zpub for native segwit
+// and xpub otherwise, tpub, upub and vpub on bitcoin testnets
+func (c Chain) ExtendedPublicVersion() []byte {
+	switch c.Address {
+	case AddressP2SHP2WPKH:
+		return btc.ExtendedPublicKeyVersion(49, c.Params)
+	case AddressP2WPKH:
+		return btc.ExtendedPublicKeyVersion(84, c.Params)
+	default:
+		return btc.ExtendedPublicKeyVersion(44, c.Params)
+	}
+}
+
+// AccountXPub returns the extended public key of m/purpose'/coin'/account',
+// see NewWatchOnly
+func (w *Wallet) AccountXPub(chain Chain, account uint32) (string, error) {
+	if err := chain.Validate(); err != nil {
+		return "", err
+	}
+	key, err := derive(w.master, chain.AccountPath(account), w.legacy)
+	if err != nil {
+		return "", err
+	}
+	defer key.Zero()
+	public, err := key.Neuter()
+	if err != nil {
+		return "", err
+	}
+	public, err = public.CloneWithVersion(chain.ExtendedPublicVersion())
+	if err != nil {
+		return "", err
+	}
+	return public.String(), nil
+}
+
+// WatchOnly derives the addresses of one account from its extended public
+// key, for deposit addresses without the seed. Its accounts have no private key.
+type WatchOnly struct {
+	chain Chain
+	key   *hdkeychain.ExtendedKey
+	path  accounts.DerivationPath
+}
+
+// NewWatchOnly parses an account extended public key, xpub, ypub or zpub as
+// given by chain.ExtendedPublicVersion. The extended key does not record its
+// path: a hardened key of depth 3 is taken as the account key
+// m/purpose'/coin'/account' of chain, see NewWatchOnlyAt to state the path.
+func NewWatchOnly(chain Chain, xpub string) (*WatchOnly, error) {
+	key, err := parseExtendedPublicKey(chain, xpub)
+	if err != nil {
+		return nil, err
+	}
+	w := &WatchOnly{chain: chain, key: key}
+	if key.Depth() == 3 && key.ChildIndex() >= hdkeychain.HardenedKeyStart {
+		w.path = chain.AccountPath(key.ChildIndex() - hdkeychain.HardenedKeyStart)
+	}
+	return w, nil
+}
+
+// NewWatchOnlyAt parses the extended public key of path, which must match
+// the key depth and child index and, for account paths, the chain purpose and
+// coin type
+func NewWatchOnlyAt(chain Chain, xpub string, path accounts.DerivationPath) (*WatchOnly, error) {
+	key, err := parseExtendedPublicKey(chain, xpub)
+	if err != nil {
+		return nil, err
+	}
+	if int(key.Depth()) != len(path) || (len(path) > 0 && key.ChildIndex() != path[len(path)-1]) {
+		return nil, fmt.Errorf("extended key of depth %d and index %d is not at %s", key.Depth(), key.ChildIndex(), path)
+	}
+	if len(path) >= 2 && (path[0] != hardened(chain.Purpose) || path[1] != hardened(chain.CoinType)) {
+		return nil, fmt.Errorf("path %s is not a %s path, m/%d'/%d'", path, chain.Name, chain.Purpose, chain.CoinType)
+	}
+	return &WatchOnly{chain: chain, key: key, path: append(accounts.DerivationPath{}, path...)}, nil
+}
+
+// parseExtendedPublicKey parses xpub, checking its version against the chain
+func parseExtendedPublicKey(chain Chain, xpub string) (*hdkeychain.ExtendedKey, error) {
+	if err := chain.Validate(); err != nil {
+		return nil, err
+	}
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsPrivate() {
+		key.Zero()
+		return nil, fmt.Errorf("extended key is private, use the extended public key")
+	}
+	if version := chain.ExtendedPublicVersion(); !bytes.Equal(key.Version(), version) {
+		return nil, fmt.Errorf("extended key version %x is not the %s version %x", key.Version(), chain.Name, version)
+	}
+	return key, nil
+}
+
+// Chain returns the chain the addresses are encoded for
+func (w *WatchOnly) Chain() Chain {
+	return w.chain
+}
+
+// Derive returns the account change/index below the extended public key. The
+// account path is only complete when the key is an account key, otherwise it
+// holds change and index only.
+func (w *WatchOnly) Derive(change, index uint32) (*Account, error) {
+	if change >= hdkeychain.HardenedKeyStart || index >= hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("cannot derive hardened keys from an extended public key")
+	}
+	changeKey, err := w.key.Derive(change)
+	if err != nil {
+		return nil, err
+	}
+	key, err := changeKey.Derive(index)
+	if err != nil {
+		return nil, err
+	}
+	path := append(append(accounts.DerivationPath{}, w.path...), change, index)
+	return newAccount(w.chain, path, key)
+}
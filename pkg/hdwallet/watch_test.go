@@ -0,0 +1,111 @@
+package hdwallet_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg"
+	"github.com/EntySquare/chain-util/pkg/btc"
+	"github.com/EntySquare/chain-util/pkg/hdwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// BIP-44/49/84/86 account extended public keys
+func TestAccountXPub(t *testing.T) {
+	w, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(t, err)
+
+	vectors := map[string]hdwallet.Chain{
+		"xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj": hdwallet.BTCLegacy,
+		"ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP": hdwallet.BTCNestedSegwit,
+		"zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs": hdwallet.BTCNativeSegwit,
+		"xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ": hdwallet.BTCTaproot,
+	}
+	for xpub, chain := range vectors {
+		got, err := w.AccountXPub(chain, 0)
+		require.Nil(t, err)
+		assert.Equal(t, xpub, got, chain.Name)
+
+		got, err = btc.AccountExtendedPublicKey(testMnemonic, chain.Purpose, 0, &chaincfg.MainNetParams)
+		require.Nil(t, err)
+		assert.Equal(t, xpub, got, chain.Name)
+	}
+
+	// a testnet vpub derives the addresses of the Generate functions
+	vpub, err := btc.AccountExtendedPublicKey(testMnemonic, 84, 0, &chaincfg.TestNet3Params)
+	require.Nil(t, err)
+	testnet := hdwallet.Chain{Name: "btc-testnet-coin0", Purpose: 84, CoinType: 0, Address: hdwallet.AddressP2WPKH, Params: &chaincfg.TestNet3Params}
+	watch, err := hdwallet.NewWatchOnly(testnet, vpub)
+	require.Nil(t, err)
+	account, err := watch.Derive(0, 0)
+	require.Nil(t, err)
+	address, _, err := btc.GenerateNativeSegWitAddressFromMnemonic(testMnemonic, &chaincfg.TestNet3Params)
+	require.Nil(t, err)
+	assert.Equal(t, address, account.Address)
+}
+
+func TestWatchOnly(t *testing.T) {
+	w, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+	require.Nil(t, err)
+
+	for _, chain := range []hdwallet.Chain{hdwallet.TRON, hdwallet.ETH, hdwallet.BTCLegacy, hdwallet.BTCNestedSegwit, hdwallet.BTCNativeSegwit, hdwallet.BTCTaproot} {
+		xpub, err := w.AccountXPub(chain, 1)
+		require.Nil(t, err)
+		watch, err := hdwallet.NewWatchOnly(chain, xpub)
+		require.Nil(t, err)
+		for _, v := range [][2]uint32{{0, 0}, {0, 5}, {1, 3}, {0, 1000000}} {
+			expected, err := w.Derive(chain, 1, v[0], v[1])
+			require.Nil(t, err)
+			account, err := watch.Derive(v[0], v[1])
+			require.Nil(t, err)
+			assert.Equal(t, expected.Address, account.Address, chain.Name)
+			assert.Equal(t, expected.Path, account.Path)
+			assert.Equal(t, expected.PublicKey, account.PublicKey)
+			assert.True(t, account.PrivateKey.Destroyed())
+		}
+		_, err = watch.Derive(0, 1<<31)
+		assert.NotNil(t, err)
+	}
+
+	// an xpub exported by pkg.Wallet works the same
+	wallet, err := pkg.EthNewFromMnemonic(testMnemonic)
+	require.Nil(t, err)
+	path, err := pkg.ParseDerivationPath("m/44'/60'/0'")
+	require.Nil(t, err)
+	xpub, err := wallet.ExtendedPublicKey(path)
+	require.Nil(t, err)
+	watch, err := hdwallet.NewWatchOnly(hdwallet.BSC, xpub)
+	require.Nil(t, err)
+	account, err := watch.Derive(0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", account.Address)
+
+	_, err = hdwallet.NewWatchOnly(hdwallet.ETH, "xprv9s21ZrQH143K3GJpoapnV8SFfukcVBSfeCficPSGfubmSFDxo1kuHnLisriDvSnRRuL2Qrg5ggqHKNVpxR86QEC8w35uxmGoggxtQTPvfUu")
+	assert.NotNil(t, err)
+	// the version must be the one of the chain
+	zpub, err := w.AccountXPub(hdwallet.BTCNativeSegwit, 0)
+	require.Nil(t, err)
+	_, err = hdwallet.NewWatchOnly(hdwallet.BTCLegacy, zpub)
+	assert.NotNil(t, err)
+	ypub, err := w.AccountXPub(hdwallet.BTCNestedSegwit, 0)
+	require.Nil(t, err)
+	_, err = hdwallet.NewWatchOnly(hdwallet.BTCNativeSegwit, ypub)
+	assert.NotNil(t, err)
+
+	// a stated path must match the key and the chain
+	path, err = pkg.ParseDerivationPath("m/84'/0'/0'")
+	require.Nil(t, err)
+	watch, err = hdwallet.NewWatchOnlyAt(hdwallet.BTCNativeSegwit, zpub, path)
+	require.Nil(t, err)
+	account, err = watch.Derive(0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, "m/84'/0'/0'/0/0", account.Path.String())
+	assert.Equal(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", account.Address)
+	for _, wrong := range []string{"m/49'/0'/0'", "m/84'/0'/1'", "m/84'/0'"} {
+		path, err = pkg.ParseDerivationPath(wrong)
+		require.Nil(t, err)
+		_, err = hdwallet.NewWatchOnlyAt(hdwallet.BTCNativeSegwit, zpub, path)
+		assert.NotNil(t, err, wrong)
+	}
+}
@@ -0,0 +1,194 @@
+// Package secret holds private keys and mnemonics in buffers that are
+// redacted when printed, logged or marshalled and can be zeroed after use.
+package secret
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Redacted is what secrets print as
+const Redacted = "[REDACTED]"
+
+const privateKeyLength = 32
+
+var (
+	// ErrDestroyed is returned when using a destroyed secret
+	ErrDestroyed = errors.New("secret was destroyed")
+	// ErrBadKeyLength for private keys that are not 32 bytes
+	ErrBadKeyLength = errors.New("private key must be 32 bytes")
+)
+
+// buffer is shared by the copies of a secret, so Destroy wipes all of them
+// and printing the struct with reflection only shows a pointer
+type buffer struct {
+	b []byte
+}
+
+func (buf *buffer) bytes() []byte {
+	if buf == nil {
+		return nil
+	}
+	return buf.b
+}
+
+func (buf *buffer) destroy() {
+	if buf == nil {
+		return
+	}
+	Zero(buf.b)
+	buf.b = nil
+}
+
+// redact implements the printing methods of the secrets
+type redact struct{}
+
+// String implements fmt.Stringer
+func (redact) String() string { return Redacted }
+
+// GoString implements fmt.GoStringer
+func (redact) GoString() string { return Redacted }
+
+// Format implements fmt.Formatter, every verb prints Redacted
+func (redact) Format(f fmt.State, _ rune) { io.WriteString(f, Redacted) }
+
+// MarshalJSON implements json.Marshaler
+func (redact) MarshalJSON() ([]byte, error) { return json.Marshal(Redacted) }
+
+// MarshalText implements encoding.TextMarshaler
+func (redact) MarshalText() ([]byte, error) { return []byte(Redacted), nil }
+
+// PrivateKey is a secp256k1 private key
+type PrivateKey struct {
+	redact
+	buf *buffer
+}
+
+// NewPrivateKey copies the 32 bytes key
+func NewPrivateKey(key []byte) (PrivateKey, error) {
+	if len(key) != privateKeyLength {
+		return PrivateKey{}, ErrBadKeyLength
+	}
+	return PrivateKey{buf: &buffer{b: append([]byte{}, key...)}}, nil
+}
+
+// PrivateKeyFromHex parses a hex key, with or without 0x
+func PrivateKeyFromHex(key string) (PrivateKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return PrivateKey{}, fmt.Errorf("invalid private key hex")
+	}
+	defer Zero(b)
+	return NewPrivateKey(b)
+}
+
+// PrivateKeyFromECDSA copies key
+func PrivateKeyFromECDSA(key *ecdsa.PrivateKey) PrivateKey {
+	return PrivateKey{buf: &buffer{b: math.PaddedBigBytes(key.D, privateKeyLength)}}
+}
+
+// Reveal returns the key bytes, valid until Destroy. Callers must not keep them.
+func (k PrivateKey) Reveal() []byte {
+	return k.buf.bytes()
+}
+
+// RevealHex returns the key as hex without 0x, a string that cannot be wiped
+func (k PrivateKey) RevealHex() string {
+	return hex.EncodeToString(k.buf.bytes())
+}
+
+// ToECDSA returns the key for signing, zero its D once done
+func (k PrivateKey) ToECDSA() (*ecdsa.PrivateKey, error) {
+	if k.Destroyed() {
+		return nil, ErrDestroyed
+	}
+	return crypto.ToECDSA(k.buf.bytes())
+}
+
+// PublicKey returns the public key
+func (k PrivateKey) PublicKey() (*ecdsa.PublicKey, error) {
+	key, err := k.ToECDSA()
+	if err != nil {
+		return nil, err
+	}
+	defer ZeroECDSA(key)
+	public := key.PublicKey
+	return &public, nil
+}
+
+// Destroyed returns true once the key is destroyed, or was never set
+func (k PrivateKey) Destroyed() bool {
+	return len(k.buf.bytes()) == 0
+}
+
+// Destroy zeroes the key
+func (k PrivateKey) Destroy() {
+	k.buf.destroy()
+}
+
+// Mnemonic is a BIP-39 mnemonic
+type Mnemonic struct {
+	redact
+	buf *buffer
+}
+
+// NewMnemonic wraps a mnemonic, normalizing the spaces
+func NewMnemonic(mnemonic string) Mnemonic {
+	return Mnemonic{buf: &buffer{b: []byte(strings.Join(strings.Fields(mnemonic), " "))}}
+}
+
+// Reveal returns the words, a string that cannot be wiped
+func (m Mnemonic) Reveal() string {
+	return string(m.buf.bytes())
+}
+
+// Valid checks the words and checksum
+func (m Mnemonic) Valid() bool {
+	return !m.Destroyed() && bip39.IsMnemonicValid(m.Reveal())
+}
+
+// Seed returns the BIP-39 seed with passphrase, zero it once done. Like
+// bip39.NewSeed it does not check the words, see Valid.
+func (m Mnemonic) Seed(passphrase string) ([]byte, error) {
+	if m.Destroyed() {
+		return nil, ErrDestroyed
+	}
+	return bip39.NewSeed(m.Reveal(), passphrase), nil
+}
+
+// Destroyed returns true once the mnemonic is destroyed, or was never set
+func (m Mnemonic) Destroyed() bool {
+	return len(m.buf.bytes()) == 0
+}
+
+// Destroy zeroes the mnemonic
+func (m Mnemonic) Destroy() {
+	m.buf.destroy()
+}
+
+// ZeroECDSA zeroes a private key in memory
+func ZeroECDSA(k *ecdsa.PrivateKey) {
+	if k == nil || k.D == nil {
+		return
+	}
+	b := k.D.Bits()
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Zero zeroes b
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,100 @@
+package secret_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg"
+	"github.com/EntySquare/chain-util/pkg/secret"
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	testKeyHex   = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+)
+
+func TestRedaction(t *testing.T) {
+	key, err := secret.PrivateKeyFromHex("0x" + testKeyHex)
+	require.Nil(t, err)
+	mnemonic := secret.NewMnemonic(testMnemonic)
+
+	holder := struct {
+		Key      secret.PrivateKey
+		Mnemonic secret.Mnemonic
+		key      secret.PrivateKey
+		mnemonic *secret.Mnemonic
+	}{key, mnemonic, key, &mnemonic}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d"} {
+		for _, v := range []interface{}{key, mnemonic, &key, holder, &holder} {
+			out := fmt.Sprintf(format, v)
+			assert.NotContains(t, strings.ToLower(out), testKeyHex[:16], format)
+			assert.NotContains(t, out, "abandon", format)
+		}
+	}
+	assert.Equal(t, secret.Redacted, key.String())
+	assert.Equal(t, secret.Redacted, fmt.Sprint(mnemonic))
+
+	out, err := json.Marshal(holder)
+	require.Nil(t, err)
+	assert.Equal(t, `{"Key":"[REDACTED]","Mnemonic":"[REDACTED]"}`, string(out))
+}
+
+func TestRevealDestroy(t *testing.T) {
+	key, err := secret.PrivateKeyFromHex(testKeyHex)
+	require.Nil(t, err)
+	assert.Equal(t, testKeyHex, key.RevealHex())
+
+	revealed := key.Reveal()
+	copied := key
+	key.Destroy()
+	assert.True(t, copied.Destroyed())
+	assert.Equal(t, make([]byte, 32), revealed)
+	_, err = copied.ToECDSA()
+	assert.Equal(t, secret.ErrDestroyed, err)
+
+	mnemonic := secret.NewMnemonic("  " + strings.ReplaceAll(testMnemonic, " ", "\n ") + " ")
+	assert.Equal(t, testMnemonic, mnemonic.Reveal())
+	assert.True(t, mnemonic.Valid())
+	mnemonic.Destroy()
+	assert.Equal(t, "", mnemonic.Reveal())
+	_, err = mnemonic.Seed("")
+	assert.Equal(t, secret.ErrDestroyed, err)
+
+	_, err = secret.NewPrivateKey(make([]byte, 31))
+	assert.Equal(t, secret.ErrBadKeyLength, err)
+}
+
+func TestSecretAPIs(t *testing.T) {
+	address, privateKey, err := pkg.EthGenerateAddressFromMnemonic(testMnemonic)
+	require.Nil(t, err)
+	secretAddress, key, err := pkg.EthGenerateAddressFromMnemonicSecret(secret.NewMnemonic(testMnemonic))
+	require.Nil(t, err)
+	assert.Equal(t, address, secretAddress)
+	assert.Equal(t, privateKey, key.RevealHex())
+	fromKey, err := pkg.EthAddressFromSecret(key)
+	require.Nil(t, err)
+	assert.Equal(t, address, fromKey)
+
+	address, privateKey, err = tron.GenerateAddressFromMnemonic(testMnemonic)
+	require.Nil(t, err)
+	secretAddress, key, err = tron.GenerateAddressFromMnemonicSecret(secret.NewMnemonic(testMnemonic))
+	require.Nil(t, err)
+	assert.Equal(t, address, secretAddress)
+	assert.Equal(t, privateKey, key.RevealHex())
+	fromKey, err = tron.AddressFromPrivateKeySecret(key)
+	require.Nil(t, err)
+	assert.Equal(t, address, fromKey)
+
+	address, key, mnemonic, err := tron.CreateWalletSecret()
+	require.Nil(t, err)
+	assert.True(t, mnemonic.Valid())
+	fromKey, err = tron.AddressFromPrivateKey(key.RevealHex())
+	require.Nil(t, err)
+	assert.Equal(t, address, fromKey)
+}
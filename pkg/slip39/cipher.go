@@ -0,0 +1,53 @@
+package slip39
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	baseIterationCount = 10000
+	roundCount         = 4
+)
+
+// feistelRound is the round function F(i, R) of the four round Feistel network
+func feistelRound(i int, passphrase, salt, r []byte, iterationExponent uint8) []byte {
+	password := append([]byte{byte(i)}, passphrase...)
+	iterations := (baseIterationCount << iterationExponent) / roundCount
+	return pbkdf2.Key(password, append(append([]byte{}, salt...), r...), iterations, len(r), sha256.New)
+}
+
+func cipherSalt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	salt := []byte("shamir")
+	return binary.BigEndian.AppendUint16(salt, identifier)
+}
+
+func feistel(data, passphrase []byte, identifier uint16, iterationExponent uint8, extendable bool, rounds []int) []byte {
+	half := len(data) / 2
+	l := append([]byte{}, data[:half]...)
+	r := append([]byte{}, data[half:]...)
+	salt := cipherSalt(identifier, extendable)
+	for _, i := range rounds {
+		f := feistelRound(i, passphrase, salt, r, iterationExponent)
+		for j := range l {
+			l[j] ^= f[j]
+		}
+		l, r = r, l
+	}
+	return append(r, l...)
+}
+
+// encrypt returns the encrypted master secret
+func encrypt(masterSecret, passphrase []byte, identifier uint16, iterationExponent uint8, extendable bool) []byte {
+	return feistel(masterSecret, passphrase, identifier, iterationExponent, extendable, []int{0, 1, 2, 3})
+}
+
+// decrypt returns the master secret of an encrypted master secret
+func decrypt(encrypted, passphrase []byte, identifier uint16, iterationExponent uint8, extendable bool) []byte {
+	return feistel(encrypted, passphrase, identifier, iterationExponent, extendable, []int{3, 2, 1, 0})
+}
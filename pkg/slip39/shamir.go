@@ -0,0 +1,136 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+const (
+	digestIndex  = 254
+	secretIndex  = 255
+	digestLength = 4
+)
+
+// expTable and logTable are GF(256) with the Rijndael polynomial x^8+x^4+x^3+x+1
+var expTable, logTable = func() (exp [255]byte, log [256]byte) {
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+		// multiply poly by the generator x+1
+		poly = (poly << 1) ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+	return exp, log
+}()
+
+type rawShare struct {
+	x     byte
+	value []byte
+}
+
+// interpolate evaluates at x the polynomial going through the shares
+func interpolate(shares []rawShare, x byte) ([]byte, error) {
+	for _, s := range shares {
+		if s.x == x {
+			return append([]byte{}, s.value...), nil
+		}
+	}
+	length := len(shares[0].value)
+	logProd := 0
+	for _, s := range shares {
+		if len(s.value) != length {
+			return nil, fmt.Errorf("all share values must have the same length")
+		}
+		logProd += int(logTable[s.x^x])
+	}
+
+	result := make([]byte, length)
+	for i, s := range shares {
+		logBasis := logProd - int(logTable[s.x^x])
+		for j, other := range shares {
+			if i != j {
+				logBasis -= int(logTable[s.x^other.x])
+			}
+		}
+		logBasis = ((logBasis % 255) + 255) % 255
+		for k, v := range s.value {
+			if v != 0 {
+				result[k] ^= expTable[(int(logTable[v])+logBasis)%255]
+			}
+		}
+	}
+	return result, nil
+}
+
+func shareDigest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// splitSecret splits secret into count shares, any threshold of them recover it
+func splitSecret(threshold, count int, secret []byte) ([]rawShare, error) {
+	if threshold < 1 || threshold > count {
+		return nil, fmt.Errorf("threshold %d must be between 1 and the share count %d", threshold, count)
+	}
+	if count > maxShareCount {
+		return nil, fmt.Errorf("share count %d must not exceed %d", count, maxShareCount)
+	}
+	shares := make([]rawShare, 0, count)
+	if threshold == 1 {
+		for i := 0; i < count; i++ {
+			shares = append(shares, rawShare{x: byte(i), value: append([]byte{}, secret...)})
+		}
+		return shares, nil
+	}
+
+	for i := 0; i < threshold-2; i++ {
+		value := make([]byte, len(secret))
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), value: value})
+	}
+	randomPart := make([]byte, len(secret)-digestLength)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := append(shareDigest(randomPart, secret), randomPart...)
+
+	base := append(append([]rawShare{}, shares...),
+		rawShare{x: digestIndex, value: digest},
+		rawShare{x: secretIndex, value: secret},
+	)
+	for i := threshold - 2; i < count; i++ {
+		value, err := interpolate(base, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), value: value})
+	}
+	return shares, nil
+}
+
+// recoverSecret combines threshold shares, checking the digest share
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	if threshold == 1 {
+		return append([]byte{}, shares[0].value...), nil
+	}
+	secret, err := interpolate(shares, secretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := interpolate(shares, digestIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(digest[:digestLength], shareDigest(digest[digestLength:], secret)) {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
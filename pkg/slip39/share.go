@@ -0,0 +1,174 @@
+package slip39
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	radixBits          = 10
+	headerWords        = 4 // identifier, extendable, exponent then the group and member fields
+	checksumWords      = 3
+	minSecretBytes     = 16
+	minMnemonicWords   = headerWords + checksumWords + (minSecretBytes*8+radixBits-1)/radixBits
+	maxShareCount      = 16
+	maxIterationExp    = 15
+	customization      = "shamir"
+	customizationExtra = "shamir_extendable"
+)
+
+var rs1024Generator = [10]uint32{
+	0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+	0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+}
+
+func rs1024Polymod(values []int) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ uint32(v)
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 != 0 {
+				chk ^= rs1024Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func checksumPrefix(extendable bool) []int {
+	cs := customization
+	if extendable {
+		cs = customizationExtra
+	}
+	prefix := make([]int, len(cs))
+	for i, c := range cs {
+		prefix[i] = int(c)
+	}
+	return prefix
+}
+
+func rs1024Checksum(data []int, extendable bool) []int {
+	values := append(append(checksumPrefix(extendable), data...), make([]int, checksumWords)...)
+	polymod := rs1024Polymod(values) ^ 1
+	checksum := make([]int, checksumWords)
+	for i := range checksum {
+		checksum[i] = int(polymod>>(radixBits*uint(checksumWords-1-i))) & 1023
+	}
+	return checksum
+}
+
+func rs1024Verify(data []int, extendable bool) bool {
+	return rs1024Polymod(append(checksumPrefix(extendable), data...)) == 1
+}
+
+// Share is a decoded share mnemonic
+type Share struct {
+	Identifier        uint16 // 15 bits, common to all the shares of a secret
+	Extendable        bool
+	IterationExponent uint8
+	GroupIndex        int
+	GroupThreshold    int
+	GroupCount        int
+	MemberIndex       int
+	MemberThreshold   int
+	value             []byte
+}
+
+// commonParameters the fields every share of one secret must agree on
+func (s *Share) commonParameters() [5]int {
+	ext := 0
+	if s.Extendable {
+		ext = 1
+	}
+	return [5]int{int(s.Identifier), ext, int(s.IterationExponent), s.GroupThreshold, s.GroupCount}
+}
+
+// Words encodes the share as a mnemonic
+func (s *Share) Words() []string {
+	header := uint64(s.Identifier)<<25 | uint64(s.IterationExponent)<<20 |
+		uint64(s.GroupIndex)<<16 | uint64(s.GroupThreshold-1)<<12 | uint64(s.GroupCount-1)<<8 |
+		uint64(s.MemberIndex)<<4 | uint64(s.MemberThreshold-1)
+	if s.Extendable {
+		header |= 1 << 24
+	}
+	data := make([]int, 0, headerWords+len(s.value))
+	for i := headerWords - 1; i >= 0; i-- {
+		data = append(data, int(header>>(radixBits*uint(i)))&1023)
+	}
+
+	valueWords := (len(s.value)*8 + radixBits - 1) / radixBits
+	value := new(big.Int).SetBytes(s.value)
+	mask := big.NewInt(1023)
+	for i := valueWords - 1; i >= 0; i-- {
+		word := new(big.Int).Rsh(value, uint(radixBits*i))
+		data = append(data, int(word.And(word, mask).Int64()))
+	}
+	data = append(data, rs1024Checksum(data, s.Extendable)...)
+
+	words := make([]string, len(data))
+	for i, index := range data {
+		words[i] = wordlist[index]
+	}
+	return words
+}
+
+// Mnemonic returns the share words joined by spaces
+func (s *Share) Mnemonic() string {
+	return strings.Join(s.Words(), " ")
+}
+
+// ParseShare decodes and checks a share mnemonic
+func ParseShare(mnemonic string) (*Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < minMnemonicWords {
+		return nil, fmt.Errorf("%w: share must be at least %d words, got %d", ErrInvalidMnemonic, minMnemonicWords, len(words))
+	}
+	data := make([]int, len(words))
+	for i, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, word)
+		}
+		data[i] = index
+	}
+
+	valueWords := len(words) - headerWords - checksumWords
+	padding := (radixBits * valueWords) % 16
+	if padding > 8 {
+		return nil, fmt.Errorf("%w: invalid share length %d", ErrInvalidMnemonic, len(words))
+	}
+
+	var header uint64
+	for _, index := range data[:headerWords] {
+		header = header<<radixBits | uint64(index)
+	}
+	share := &Share{
+		Identifier:        uint16(header >> 25),
+		Extendable:        (header>>24)&1 == 1,
+		IterationExponent: uint8(header>>20) & 0xf,
+		GroupIndex:        int(header>>16) & 0xf,
+		GroupThreshold:    int(header>>12)&0xf + 1,
+		GroupCount:        int(header>>8)&0xf + 1,
+		MemberIndex:       int(header>>4) & 0xf,
+		MemberThreshold:   int(header)&0xf + 1,
+	}
+	if !rs1024Verify(data, share.Extendable) {
+		return nil, ErrInvalidChecksum
+	}
+	if share.GroupThreshold > share.GroupCount {
+		return nil, fmt.Errorf("%w: group threshold %d exceeds the group count %d", ErrInvalidMnemonic, share.GroupThreshold, share.GroupCount)
+	}
+
+	value := new(big.Int)
+	for _, index := range data[headerWords : len(data)-checksumWords] {
+		value.Lsh(value, radixBits).Or(value, big.NewInt(int64(index)))
+	}
+	length := (radixBits*valueWords - padding) / 8
+	if value.BitLen() > length*8 {
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidMnemonic)
+	}
+	share.value = value.FillBytes(make([]byte, length))
+	return share, nil
+}
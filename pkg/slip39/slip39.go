@@ -0,0 +1,212 @@
+// Package slip39 implements SLIP-39 Shamir backups: a master secret is split
+// into groups of share mnemonics, any group threshold of groups each holding
+// its member threshold of shares recovers it.
+//
+// See https://github.com/satoshilabs/slips/blob/master/slip-0039.md
+package slip39
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/EntySquare/chain-util/pkg/secret"
+	"github.com/tyler-smith/go-bip39"
+)
+
+var (
+	// ErrInvalidMnemonic for a share that cannot be decoded
+	ErrInvalidMnemonic = errors.New("invalid share mnemonic")
+	// ErrInvalidChecksum for a share with a wrong checksum
+	ErrInvalidChecksum = errors.New("invalid share mnemonic checksum")
+	// ErrMismatchedShares for shares that belong to different secrets
+	ErrMismatchedShares = errors.New("shares do not belong to the same secret")
+	// ErrInsufficientShares for share sets below the thresholds
+	ErrInsufficientShares = errors.New("insufficient shares to recover the secret")
+	// ErrInvalidDigest for share sets failing the secret digest check
+	ErrInvalidDigest = errors.New("invalid shares, the secret digest does not match")
+	// ErrInvalidPassphrase for passphrases with other than printable ASCII characters
+	ErrInvalidPassphrase = errors.New("passphrase must only contain printable ASCII characters")
+)
+
+// Group is the member threshold and count of one group of shares
+type Group struct {
+	Threshold int
+	Count     int
+}
+
+// Options configures Split
+type Options struct {
+	// GroupThreshold is the number of groups needed to recover the secret
+	GroupThreshold int
+	Groups         []Group
+	// Passphrase encrypts the master secret, the same passphrase is needed to
+	// recover it and a different one silently returns a different secret.
+	// Only printable ASCII characters are allowed.
+	Passphrase string
+	// IterationExponent raises the PBKDF2 iterations to 10000 << e, up to 15
+	IterationExponent uint8
+}
+
+// Split splits secret into share mnemonics, one slice per group
+func Split(secret []byte, opts Options) ([][]string, error) {
+	if len(secret) < minSecretBytes || len(secret)%2 != 0 {
+		return nil, fmt.Errorf("secret must be at least %d bytes and an even length, got %d", minSecretBytes, len(secret))
+	}
+	if err := checkPassphrase(opts.Passphrase); err != nil {
+		return nil, err
+	}
+	if opts.IterationExponent > maxIterationExp {
+		return nil, fmt.Errorf("iteration exponent must not exceed %d", maxIterationExp)
+	}
+	if len(opts.Groups) == 0 || len(opts.Groups) > maxShareCount {
+		return nil, fmt.Errorf("group count must be between 1 and %d, got %d", maxShareCount, len(opts.Groups))
+	}
+	if opts.GroupThreshold < 1 || opts.GroupThreshold > len(opts.Groups) {
+		return nil, fmt.Errorf("group threshold %d must be between 1 and the group count %d", opts.GroupThreshold, len(opts.Groups))
+	}
+	for i, g := range opts.Groups {
+		if g.Threshold == 1 && g.Count > 1 {
+			return nil, fmt.Errorf("group %d: use 1-of-1 instead of 1-of-%d member shares", i, g.Count)
+		}
+		if g.Threshold < 1 || g.Threshold > g.Count || g.Count > maxShareCount {
+			return nil, fmt.Errorf("group %d: member threshold %d must be between 1 and the member count %d (at most %d)",
+				i, g.Threshold, g.Count, maxShareCount)
+		}
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & 0x7fff
+	encrypted := encrypt(secret, []byte(opts.Passphrase), identifier, opts.IterationExponent, true)
+
+	groupShares, err := splitSecret(opts.GroupThreshold, len(opts.Groups), encrypted)
+	if err != nil {
+		return nil, err
+	}
+	mnemonics := make([][]string, len(opts.Groups))
+	for i, group := range opts.Groups {
+		memberShares, err := splitSecret(group.Threshold, group.Count, groupShares[i].value)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range memberShares {
+			share := &Share{
+				Identifier:        identifier,
+				Extendable:        true,
+				IterationExponent: opts.IterationExponent,
+				GroupIndex:        int(groupShares[i].x),
+				GroupThreshold:    opts.GroupThreshold,
+				GroupCount:        len(opts.Groups),
+				MemberIndex:       int(member.x),
+				MemberThreshold:   group.Threshold,
+				value:             member.value,
+			}
+			mnemonics[i] = append(mnemonics[i], share.Mnemonic())
+		}
+	}
+	return mnemonics, nil
+}
+
+// Combine recovers the master secret from share mnemonics of any groups.
+// Groups short of their member threshold are ignored.
+func Combine(mnemonics []string, passphrase string) ([]byte, error) {
+	if err := checkPassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if len(mnemonics) == 0 {
+		return nil, ErrInsufficientShares
+	}
+	shares := make([]*Share, len(mnemonics))
+	for i, mnemonic := range mnemonics {
+		share, err := ParseShare(mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares[i] = share
+	}
+
+	first := shares[0]
+	groups := make(map[int][]*Share)
+	for i, share := range shares {
+		if share.commonParameters() != first.commonParameters() {
+			return nil, fmt.Errorf("%w: share %d has identifier %d, expected %d",
+				ErrMismatchedShares, i+1, share.Identifier, first.Identifier)
+		}
+		for _, member := range groups[share.GroupIndex] {
+			if member.MemberThreshold != share.MemberThreshold {
+				return nil, fmt.Errorf("%w: group %d mixes member thresholds", ErrMismatchedShares, share.GroupIndex)
+			}
+			if member.MemberIndex == share.MemberIndex {
+				if string(member.value) != string(share.value) {
+					return nil, fmt.Errorf("%w: group %d has conflicting member %d", ErrMismatchedShares, share.GroupIndex, share.MemberIndex)
+				}
+				share = nil
+				break
+			}
+		}
+		if share != nil {
+			groups[share.GroupIndex] = append(groups[share.GroupIndex], share)
+		}
+	}
+
+	indexes := make([]int, 0, len(groups))
+	for index, members := range groups {
+		if len(members) >= members[0].MemberThreshold {
+			indexes = append(indexes, index)
+		}
+	}
+	if len(indexes) < first.GroupThreshold {
+		return nil, fmt.Errorf("%w: %d complete groups of the %d required", ErrInsufficientShares, len(indexes), first.GroupThreshold)
+	}
+	sort.Ints(indexes)
+
+	groupShares := make([]rawShare, 0, first.GroupThreshold)
+	for _, index := range indexes[:first.GroupThreshold] {
+		members := groups[index]
+		threshold := members[0].MemberThreshold
+		memberShares := make([]rawShare, threshold)
+		for i, member := range members[:threshold] {
+			memberShares[i] = rawShare{x: byte(member.MemberIndex), value: member.value}
+		}
+		groupSecret, err := recoverSecret(threshold, memberShares)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", index, err)
+		}
+		groupShares = append(groupShares, rawShare{x: byte(index), value: groupSecret})
+	}
+	encrypted, err := recoverSecret(first.GroupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, []byte(passphrase), first.Identifier, first.IterationExponent, first.Extendable), nil
+}
+
+// checkPassphrase enforces the printable ASCII passphrases of the spec
+func checkPassphrase(passphrase string) error {
+	for i := 0; i < len(passphrase); i++ {
+		if passphrase[i] < 32 || passphrase[i] > 126 {
+			return ErrInvalidPassphrase
+		}
+	}
+	return nil
+}
+
+// SplitMnemonic splits the BIP-39 seed of mnemonic and its BIP-39
+// passphrase. The seed is the master secret of the spec, the BIP-32 seed of
+// the wallet: Combine returns it, hdwallet.NewFromShares and
+// pkg.EthNewFromSeed restore the wallet, and so do other SLIP-39 wallets
+// accepting 512 bit master secrets (Trezor devices only take 128 or 256 bit
+// ones and reject these shares).
+func SplitMnemonic(mnemonic, bip39Passphrase string, opts Options) ([][]string, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Zero(seed)
+	return Split(seed, opts)
+}
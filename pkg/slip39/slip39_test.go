@@ -0,0 +1,123 @@
+package slip39_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg"
+	"github.com/EntySquare/chain-util/pkg/slip39"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SLIP-39 test vectors, passphrase TREZOR
+func TestCombineVectors(t *testing.T) {
+	vectors := []struct {
+		mnemonics []string
+		secret    string
+	}{
+		{
+			[]string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"},
+			"bb54aac4b89dc868ba37d9cc21b2cece",
+		},
+		{
+			[]string{"theory painting academic academic armed sweater year military elder discuss acne wildlife boring employer fused large satoshi bundle carbon diagnose anatomy hamster leaves tracks paces beyond phantom capital marvel lips brave detect luck"},
+			"989baf9dcaad5b10ca33dfd8cc75e42477025dce88ae83e75a230086a0e00e92",
+		},
+	}
+	for _, v := range vectors {
+		secret, err := slip39.Combine(v.mnemonics, "TREZOR")
+		require.Nil(t, err)
+		assert.Equal(t, v.secret, hex.EncodeToString(secret))
+	}
+
+	// last word changed
+	_, err := slip39.Combine([]string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney"}, "TREZOR")
+	assert.True(t, errors.Is(err, slip39.ErrInvalidChecksum))
+	_, err = slip39.Combine([]string{"duckling enlarge academic"}, "TREZOR")
+	assert.True(t, errors.Is(err, slip39.ErrInvalidMnemonic))
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret, _ := hex.DecodeString("989baf9dcaad5b10ca33dfd8cc75e42477025dce88ae83e75a230086a0e00e92")
+	opts := slip39.Options{
+		GroupThreshold: 2,
+		Groups:         []slip39.Group{{Threshold: 1, Count: 1}, {Threshold: 2, Count: 3}, {Threshold: 3, Count: 5}},
+		Passphrase:     "treasury",
+	}
+	groups, err := slip39.Split(secret, opts)
+	require.Nil(t, err)
+	require.Len(t, groups, 3)
+	assert.Len(t, groups[1], 3)
+	assert.Len(t, strings.Fields(groups[1][0]), 33)
+
+	recovered, err := slip39.Combine([]string{groups[0][0], groups[1][2], groups[1][0]}, "treasury")
+	require.Nil(t, err)
+	assert.Equal(t, secret, recovered)
+	recovered, err = slip39.Combine([]string{groups[2][4], groups[1][1], groups[2][0], groups[1][2], groups[2][2]}, "treasury")
+	require.Nil(t, err)
+	assert.Equal(t, secret, recovered)
+
+	// a wrong passphrase gives another secret, not an error
+	recovered, err = slip39.Combine([]string{groups[0][0], groups[1][0], groups[1][1]}, "")
+	require.Nil(t, err)
+	assert.NotEqual(t, secret, recovered)
+
+	_, err = slip39.Combine([]string{groups[0][0], groups[1][0]}, "treasury")
+	assert.True(t, errors.Is(err, slip39.ErrInsufficientShares))
+
+	other, err := slip39.Split(secret, opts)
+	require.Nil(t, err)
+	_, err = slip39.Combine([]string{groups[0][0], other[1][0], other[1][1]}, "treasury")
+	assert.True(t, errors.Is(err, slip39.ErrMismatchedShares))
+
+	_, err = slip39.Split(secret[:15], opts)
+	assert.NotNil(t, err)
+	_, err = slip39.Split(secret, slip39.Options{GroupThreshold: 1, Groups: []slip39.Group{{Threshold: 1, Count: 3}}})
+	assert.NotNil(t, err)
+
+	// passphrases are printable ASCII only
+	for _, passphrase := range []string{"trésor", "tab\there", "line\n"} {
+		opts.Passphrase = passphrase
+		_, err = slip39.Split(secret, opts)
+		assert.True(t, errors.Is(err, slip39.ErrInvalidPassphrase), passphrase)
+		_, err = slip39.Combine(groups[0], passphrase)
+		assert.True(t, errors.Is(err, slip39.ErrInvalidPassphrase), passphrase)
+	}
+	opts.Passphrase = " !~"
+	_, err = slip39.Split(secret, opts)
+	assert.Nil(t, err)
+}
+
+func TestSplitMnemonic(t *testing.T) {
+	mnemonic, err := pkg.GetMnemonicBy256()
+	require.Nil(t, err)
+	groups, err := slip39.SplitMnemonic(mnemonic, "", slip39.Options{
+		GroupThreshold: 1,
+		Groups:         []slip39.Group{{Threshold: 2, Count: 3}},
+	})
+	require.Nil(t, err)
+	_, err = slip39.SplitMnemonic("not a mnemonic", "", slip39.Options{GroupThreshold: 1, Groups: []slip39.Group{{Threshold: 1, Count: 1}}})
+	assert.NotNil(t, err)
+
+	// the master secret is the BIP-39 seed, as other SLIP-39 wallets expect
+	seed, err := pkg.NewSeedFromMnemonic(mnemonic)
+	require.Nil(t, err)
+	recovered, err := slip39.Combine([]string{groups[0][2], groups[0][1]}, "")
+	require.Nil(t, err)
+	assert.Equal(t, seed, recovered)
+
+	path, err := pkg.MustParseDerivationPath(pkg.TronDerivationPath)
+	require.Nil(t, err)
+	original, err := pkg.EthNewFromMnemonic(mnemonic)
+	require.Nil(t, err)
+	restored, err := pkg.EthNewFromSeed(recovered)
+	require.Nil(t, err)
+	a, err := original.Derive(path, false)
+	require.Nil(t, err)
+	b, err := restored.Derive(path, false)
+	require.Nil(t, err)
+	assert.Equal(t, a.Address, b.Address)
+}
@@ -43,52 +43,204 @@ func Signature(method string) []byte {
 }
 
 func convetToAddress(v interface{}) (eCommon.Address, error) {
-	switch v.(type) {
+	switch v := v.(type) {
 	case string:
-		addr, err := tron.Base58ToAddress(v.(string))
+		addr, err := tron.Base58ToAddress(v)
 		if err != nil {
-			return eCommon.Address{}, fmt.Errorf("invalid address %s: %+v", v.(string), err)
+			return eCommon.Address{}, fmt.Errorf("invalid address %s: %+v", v, err)
 		}
 		return eCommon.BytesToAddress(addr.Bytes()[len(addr.Bytes())-20:]), nil
+	case tron.Address:
+		if len(v) != tron.AddressLength {
+			return eCommon.Address{}, fmt.Errorf("invalid address %s", v.Hex())
+		}
+		return eCommon.BytesToAddress(v.Bytes()[len(v.Bytes())-20:]), nil
+	case eCommon.Address:
+		return v, nil
 	}
 	return eCommon.Address{}, fmt.Errorf("invalid address %v", v)
 }
 
-func convertToInt(ty eABI.Type, v interface{}) interface{} {
-	if ty.T == eABI.IntTy && ty.Size <= 64 {
-		tmp, _ := strconv.ParseInt(v.(string), 10, ty.Size)
-		switch ty.Size {
-		case 8:
-			v = int8(tmp)
-		case 16:
-			v = int16(tmp)
-		case 32:
-			v = int32(tmp)
-		case 64:
-			v = int64(tmp)
+func convertToBigInt(v interface{}) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case string:
+		var value *big.Int
+		var ok bool
+		// check for hex char
+		if strings.HasPrefix(v, "0x") {
+			value, ok = new(big.Int).SetString(v[2:], 16)
+		} else {
+			value, ok = new(big.Int).SetString(v, 10)
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid number %s", v)
 		}
-	} else if ty.T == eABI.UintTy && ty.Size <= 64 {
-		tmp, _ := strconv.ParseUint(v.(string), 10, ty.Size)
-		switch ty.Size {
-		case 8:
-			v = uint8(tmp)
-		case 16:
-			v = uint16(tmp)
-		case 32:
-			v = uint32(tmp)
-		case 64:
-			v = uint64(tmp)
+		return value, nil
+	case json.Number:
+		return convertToBigInt(v.String())
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("invalid integer %v", v)
 		}
+		return big.NewInt(int64(v)), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("invalid number %v", v)
+}
+
+func convertToInt(ty eABI.Type, v interface{}) (reflect.Value, error) {
+	n, err := convertToBigInt(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	target := ty.GetType()
+	switch target.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value := reflect.New(target).Elem()
+		if !n.IsInt64() || value.OverflowInt(n.Int64()) {
+			return reflect.Value{}, fmt.Errorf("%s overflows %s", n, ty)
+		}
+		value.SetInt(n.Int64())
+		return value, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value := reflect.New(target).Elem()
+		if !n.IsUint64() || value.OverflowUint(n.Uint64()) {
+			return reflect.Value{}, fmt.Errorf("%s overflows %s", n, ty)
+		}
+		value.SetUint(n.Uint64())
+		return value, nil
+	}
+	return reflect.ValueOf(n), nil
+}
+
+func convertToBytes(ty eABI.Type, v interface{}) (reflect.Value, error) {
+	var dataBytes []byte
+	switch data := v.(type) {
+	case []byte:
+		dataBytes = data
+	case string:
+		// convert from hex string
+		var err error
+		dataBytes, err = hex.DecodeString(strings.TrimPrefix(data, "0x"))
+		if err != nil {
+			// try with base64
+			dataBytes, err = base64.StdEncoding.DecodeString(data)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("invalid bytes %v", v)
+	}
+	if ty.T == eABI.BytesTy {
+		return reflect.ValueOf(dataBytes), nil
+	}
+	if len(dataBytes) != ty.Size {
+		return reflect.Value{}, fmt.Errorf("invalid size: %d/%d", ty.Size, len(dataBytes))
+	}
+	value := reflect.New(ty.GetType()).Elem()
+	reflect.Copy(value, reflect.ValueOf(dataBytes))
+	return value, nil
+}
+
+func convertToList(ty eABI.Type, v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return reflect.Value{}, fmt.Errorf("unable to convert %v to %s", v, ty)
+	}
+	if ty.T == eABI.ArrayTy && rv.Len() != ty.Size {
+		return reflect.Value{}, fmt.Errorf("invalid %s length: %d", ty, rv.Len())
+	}
+	var list reflect.Value
+	if ty.T == eABI.ArrayTy {
+		list = reflect.New(ty.GetType()).Elem()
 	} else {
-		s := v.(string)
-		// check for hex char
-		if strings.HasPrefix(s, "0x") {
-			v, _ = new(big.Int).SetString(s[2:], 16)
-		} else {
-			v, _ = new(big.Int).SetString(s, 10)
+		list = reflect.MakeSlice(ty.GetType(), rv.Len(), rv.Len())
+	}
+	for i := 0; i < rv.Len(); i++ {
+		elem, err := convertToType(*ty.Elem, rv.Index(i).Interface())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s[%d]: %v", ty, i, err)
+		}
+		list.Index(i).Set(elem)
+	}
+	return list, nil
+}
+
+func convertToTuple(ty eABI.Type, v interface{}) (reflect.Value, error) {
+	values := make([]interface{}, len(ty.TupleElems))
+	switch fields := v.(type) {
+	case map[string]interface{}:
+		for i, name := range ty.TupleRawNames {
+			field, ok := fields[name]
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("missing tuple field %s", name)
+			}
+			values[i] = field
+		}
+	default:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return reflect.Value{}, fmt.Errorf("unable to convert %v to %s", v, ty)
 		}
+		if rv.Len() != len(values) {
+			return reflect.Value{}, fmt.Errorf("invalid tuple length %d/%d", rv.Len(), len(values))
+		}
+		for i := range values {
+			values[i] = rv.Index(i).Interface()
+		}
+	}
+	tuple := reflect.New(ty.TupleType).Elem()
+	for i, elem := range ty.TupleElems {
+		field, err := convertToType(*elem, values[i])
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s: %v", ty.TupleRawNames[i], err)
+		}
+		tuple.Field(i).Set(field)
 	}
-	return v
+	return tuple, nil
+}
+
+// convertToType convert JSON/string friendly values into the go type expected by the ABI packer
+func convertToType(ty eABI.Type, v interface{}) (reflect.Value, error) {
+	if v != nil && reflect.TypeOf(v) == ty.GetType() {
+		return reflect.ValueOf(v), nil
+	}
+	switch ty.T {
+	case eABI.IntTy, eABI.UintTy:
+		return convertToInt(ty, v)
+	case eABI.AddressTy:
+		addr, err := convetToAddress(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(addr), nil
+	case eABI.BoolTy:
+		if s, ok := v.(string); ok {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(b), nil
+		}
+	case eABI.BytesTy, eABI.FixedBytesTy:
+		return convertToBytes(ty, v)
+	case eABI.SliceTy, eABI.ArrayTy:
+		return convertToList(ty, v)
+	case eABI.TupleTy:
+		return convertToTuple(ty, v)
+	}
+	return reflect.Value{}, fmt.Errorf("unable to convert %v to %s", v, ty)
 }
 
 // GetPaddedParam from struct
@@ -101,7 +253,7 @@ func GetPaddedParam(param []Param) ([]byte, error) {
 			return nil, fmt.Errorf("invalid param %+v", p)
 		}
 		for k, v := range p {
-			ty, err := eABI.NewType(k, "", nil)
+			ty, err := NewType(k)
 			if err != nil {
 				return nil, fmt.Errorf("invalid param %+v: %+v", p, err)
 			}
@@ -113,110 +265,17 @@ func GetPaddedParam(param []Param) ([]byte, error) {
 				},
 			)
 
-			if ty.T == eABI.SliceTy || ty.T == eABI.ArrayTy {
-				if ty.Elem.T == eABI.AddressTy {
-					tmp, ok := v.([]interface{})
-					if !ok {
-						return nil, fmt.Errorf("unable to convert array of addresses %+v", p)
-					}
-					v = make([]eCommon.Address, 0)
-					for i := range tmp {
-						addr, err := convetToAddress(tmp[i])
-						if err != nil {
-							return nil, err
-						}
-						v = append(v.([]eCommon.Address), addr)
-					}
-				}
-
-				if (ty.Elem.T == eABI.IntTy || ty.Elem.T == eABI.UintTy) &&
-					ty.Elem.Size > 64 {
-					tmp := make([]*big.Int, 0)
-					tmpSlice, ok := v.([]string)
-					if !ok {
-						return nil, fmt.Errorf("unable to convert array of unints %+v", p)
-					}
-					for i := range tmpSlice {
-						var value *big.Int
-						// check for hex char
-						if strings.HasPrefix(tmpSlice[i], "0x") {
-							value, _ = new(big.Int).SetString(tmpSlice[i][2:], 16)
-						} else {
-							value, _ = new(big.Int).SetString(tmpSlice[i], 10)
-						}
-						tmp = append(tmp, value)
-					}
-					v = tmp
-				}
-			}
-			if ty.T == eABI.AddressTy {
-				if v, err = convetToAddress(v); err != nil {
-					return nil, err
-				}
-			}
-			if (ty.T == eABI.IntTy || ty.T == eABI.UintTy) && reflect.TypeOf(v).Kind() == reflect.String {
-				v = convertToInt(ty, v)
-			}
-
-			if ty.T == eABI.BytesTy || ty.T == eABI.FixedBytesTy {
-				var err error
-				if v, err = convertToBytes(ty, v); err != nil {
-					return nil, err
-				}
+			value, err := convertToType(ty, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid param %+v: %+v", p, err)
 			}
-
-			values = append(values, v)
+			values = append(values, value.Interface())
 		}
 	}
 	// convert params to bytes
 	return arguments.PackValues(values)
 }
 
-func convertToBytes(ty eABI.Type, v interface{}) (interface{}, error) {
-	// if string
-	if data, ok := v.(string); ok {
-		// convert from hex string
-		dataBytes, err := hex.DecodeString(data)
-		if err != nil {
-			// try with base64
-			dataBytes, err = base64.StdEncoding.DecodeString(data)
-			if err != nil {
-				return nil, err
-			}
-		}
-		// if array and size == 0
-		if ty.T == eABI.BytesTy || ty.Size == 0 {
-			return dataBytes, nil
-		}
-		if len(dataBytes) != ty.Size {
-			return nil, fmt.Errorf("invalid size: %d/%d", ty.Size, len(dataBytes))
-		}
-		switch ty.Size {
-		case 1:
-			value := [1]byte{}
-			copy(value[:], dataBytes[:1])
-			return value, nil
-		case 2:
-			value := [2]byte{}
-			copy(value[:], dataBytes[:2])
-			return value, nil
-		case 8:
-			value := [8]byte{}
-			copy(value[:], dataBytes[:8])
-			return value, nil
-		case 16:
-			value := [16]byte{}
-			copy(value[:], dataBytes[:16])
-			return value, nil
-		case 32:
-			value := [32]byte{}
-			copy(value[:], dataBytes[:32])
-			return value, nil
-		}
-	}
-	return v, nil
-}
-
 // Pack data into bytes
 func Pack(method string, param []Param) ([]byte, error) {
 	signature := Signature(method)
@@ -230,21 +289,9 @@ func Pack(method string, param []Param) ([]byte, error) {
 
 // GetParser return output method parser arguments from ABI
 func GetParser(ABI *core.SmartContract_ABI, method string) (eABI.Arguments, error) {
-	arguments := eABI.Arguments{}
 	for _, entry := range ABI.Entrys {
 		if entry.Name == method {
-			for _, out := range entry.Outputs {
-				ty, err := eABI.NewType(out.Type, "", nil)
-				if err != nil {
-					return nil, fmt.Errorf("invalid param %s: %+v", out.Type, err)
-				}
-				arguments = append(arguments, eABI.Argument{
-					Name:    out.Name,
-					Type:    ty,
-					Indexed: out.Indexed,
-				})
-			}
-			return arguments, nil
+			return NewArguments(entry.Outputs)
 		}
 	}
 	return nil, fmt.Errorf("not found")
@@ -252,21 +299,9 @@ func GetParser(ABI *core.SmartContract_ABI, method string) (eABI.Arguments, erro
 
 // GetInputsParser returns input method parser arguments from ABI
 func GetInputsParser(ABI *core.SmartContract_ABI, method string) (eABI.Arguments, error) {
-	arguments := eABI.Arguments{}
 	for _, entry := range ABI.Entrys {
 		if entry.Name == method {
-			for _, out := range entry.Inputs {
-				ty, err := eABI.NewType(out.Type, "", nil)
-				if err != nil {
-					return nil, fmt.Errorf("invalid param %s: %+v", out.Type, err)
-				}
-				arguments = append(arguments, eABI.Argument{
-					Name:    out.Name,
-					Type:    ty,
-					Indexed: out.Indexed,
-				})
-			}
-			return arguments, nil
+			return NewArguments(entry.Inputs)
 		}
 	}
 	return nil, fmt.Errorf("not found")
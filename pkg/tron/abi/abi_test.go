@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -73,3 +74,121 @@ func TestABI_HEXuint256(t *testing.T) {
 	assert.Len(t, b, 64, fmt.Sprintf("Wrong length %d/%d", len(b), 256))
 	assert.Equal(t, "000000000000000000000000000000000000000000000000000000000000abcd000000000000000000000000000000000000000000000000000000000000abcd", hex.EncodeToString(b))
 }
+
+var trc20ABI = &core.SmartContract_ABI{
+	Entrys: []*core.SmartContract_ABI_Entry{
+		{
+			Name: "transfer",
+			Type: core.SmartContract_ABI_Entry_Function,
+			Inputs: []*core.SmartContract_ABI_Entry_Param{
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+			},
+			Outputs: []*core.SmartContract_ABI_Entry_Param{{Type: "bool"}},
+		},
+		{
+			Name:    "owner",
+			Type:    core.SmartContract_ABI_Entry_Function,
+			Outputs: []*core.SmartContract_ABI_Entry_Param{{Type: "address"}},
+		},
+	},
+}
+
+func TestABI_DecodeInput(t *testing.T) {
+	data, err := Pack("transfer(address,uint256)", []Param{
+		{"address": "TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R"},
+		{"uint256": "1000"},
+	})
+	require.Nil(t, err)
+
+	method, args, err := DecodeInput(trc20ABI, data)
+	require.Nil(t, err)
+	assert.Equal(t, "transfer", method)
+	require.Len(t, args, 2)
+	assert.Equal(t, "TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R", args[0])
+	assert.Equal(t, big.NewInt(1000), args[1])
+}
+
+func TestABI_Unpack(t *testing.T) {
+	data, _ := hex.DecodeString("000000000000000000000000364b03e0815687edaf90b81ff58e496dea7383d7")
+	values, err := Unpack(trc20ABI, "owner", data)
+	require.Nil(t, err)
+	assert.Equal(t, []interface{}{"TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R"}, values)
+}
+
+func TestABI_UnpackRevert(t *testing.T) {
+	data, _ := hex.DecodeString("08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000012" +
+		"696e73756666696369656e742066756e64730000000000000000000000000000")
+	err := UnpackRevert(data)
+	require.IsType(t, &RevertError{}, err)
+	assert.Equal(t, "insufficient funds", err.(*RevertError).Reason)
+
+	data, _ = hex.DecodeString("4e487b71" +
+		"0000000000000000000000000000000000000000000000000000000000000011")
+	err = UnpackRevert(data)
+	require.IsType(t, &PanicError{}, err)
+	assert.Equal(t, int64(0x11), err.(*PanicError).Code.Int64())
+
+	assert.Nil(t, UnpackRevert([]byte{0x01, 0x02}))
+}
+
+func TestABIParamTuple(t *testing.T) {
+	param, err := LoadFromJSON(`
+	[
+		{"(address to,uint256 amount)": {"to": "TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R", "amount": "0xABCD"}},
+		{"tuple(address,uint256)": ["TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R", 43981]}
+	]
+	`)
+	require.Nil(t, err)
+	b, err := GetPaddedParam(param)
+	require.Nil(t, err)
+	word := "000000000000000000000000364b03e0815687edaf90b81ff58e496dea7383d7" +
+		"000000000000000000000000000000000000000000000000000000000000abcd"
+	assert.Equal(t, word+word, hex.EncodeToString(b))
+}
+
+func TestABIParamNested(t *testing.T) {
+	param, err := LoadFromJSON(`
+	[
+		{"address[][]": [["TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R"], ["TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R", "TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R"]]},
+		{"bytes[]": ["0102", "030405"]},
+		{"(address owner,(uint256 id,bytes data)[] items)[]": [
+			{"owner": "TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R", "items": [{"id": "1", "data": "ff"}]}
+		]}
+	]
+	`)
+	require.Nil(t, err)
+	data, err := Pack("multi(address[][],bytes[],(address,(uint256,bytes)[])[])", param)
+	require.Nil(t, err)
+
+	contractABI := &core.SmartContract_ABI{
+		Entrys: []*core.SmartContract_ABI_Entry{{
+			Name: "multi",
+			Type: core.SmartContract_ABI_Entry_Function,
+			Inputs: []*core.SmartContract_ABI_Entry_Param{
+				{Name: "holders", Type: "address[][]"},
+				{Name: "blobs", Type: "bytes[]"},
+				{Name: "orders", Type: "(address owner,(uint256 id,bytes data)[] items)[]"},
+			},
+		}},
+	}
+	method, args, err := DecodeInput(contractABI, data)
+	require.Nil(t, err)
+	assert.Equal(t, "multi", method)
+	require.Len(t, args, 3)
+	assert.Equal(t, []interface{}{
+		[]interface{}{"TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R"},
+		[]interface{}{"TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R", "TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R"},
+	}, args[0])
+	assert.Equal(t, [][]byte{{0x01, 0x02}, {0x03, 0x04, 0x05}}, args[1])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"owner": "TEvHMZWyfjCAdDJEKYxYVL8rRpigddLC1R",
+			"items": []interface{}{
+				map[string]interface{}{"id": big.NewInt(1), "data": []byte{0xff}},
+			},
+		},
+	}, args[2])
+}
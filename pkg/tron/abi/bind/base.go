@@ -0,0 +1,182 @@
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+)
+
+// TransactOpts collection of parameters to build a contract transaction
+type TransactOpts struct {
+	From       string // Base58 address of the caller
+	FeeLimit   int64  // Maximum SUN burned for energy, 0 keeps the node default
+	CallValue  int64  // SUN sent along with the call (payable methods)
+	TokenID    string // TRC10 token sent along with the call
+	TokenValue int64  // TRC10 amount sent along with the call
+}
+
+// BoundContract is the base wrapper used by generated bindings
+type BoundContract struct {
+	client  *client.GrpcClient
+	address string
+	abi     *core.SmartContract_ABI
+}
+
+// NewBoundContract creates a low level contract interface
+func NewBoundContract(address string, contractABI *core.SmartContract_ABI, c *client.GrpcClient) *BoundContract {
+	return &BoundContract{
+		client:  c,
+		address: address,
+		abi:     contractABI,
+	}
+}
+
+// Address of the bound contract in base58
+func (b *BoundContract) Address() string {
+	return b.address
+}
+
+// ABI of the bound contract
+func (b *BoundContract) ABI() *core.SmartContract_ABI {
+	return b.abi
+}
+
+// Call make a constant call and return the unpacked outputs
+func (b *BoundContract) Call(method string, param []abi.Param) ([]interface{}, error) {
+	return b.client.CallContractWithABI(b.abi, b.address, method, param)
+}
+
+// Transact build a contract call transaction, it is not signed nor broadcast
+func (b *BoundContract) Transact(opts *TransactOpts, method string, param []abi.Param) (*api.TransactionExtention, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("transact options are required")
+	}
+	entry, err := abi.GetMethodEntry(b.abi, method)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := abi.EntrySignature(entry)
+	if err != nil {
+		return nil, err
+	}
+	if opts.CallValue > 0 && !isPayable(entry) {
+		return nil, fmt.Errorf("method %s is not payable", signature)
+	}
+	return b.client.TriggerContractWithParam(opts.From, b.address, signature, param,
+		opts.FeeLimit, opts.CallValue, opts.TokenID, opts.TokenValue)
+}
+
+func isPayable(entry *core.SmartContract_ABI_Entry) bool {
+	return entry.Payable || entry.StateMutability == core.SmartContract_ABI_Entry_Payable
+}
+
+// UnpackLog decode a log of the given event
+func (b *BoundContract) UnpackLog(event string, log *core.TransactionInfo_Log) (map[string]interface{}, error) {
+	entry, err := abi.GetEventEntry(b.abi, event)
+	if err != nil {
+		return nil, err
+	}
+	return abi.UnpackLog(entry, log)
+}
+
+// FilterLogs return logs of the given event emitted by the bound contract
+func (b *BoundContract) FilterLogs(event string, infos ...*core.TransactionInfo) ([]*core.TransactionInfo_Log, error) {
+	entry, err := abi.GetEventEntry(b.abi, event)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := abi.EntrySignature(entry)
+	if err != nil {
+		return nil, err
+	}
+	topic := abi.EventTopic(signature)
+
+	contractDesc, err := tron.Base58ToAddress(b.address)
+	if err != nil {
+		return nil, err
+	}
+	// logs carry the address without the TRON prefix
+	address := contractDesc.Bytes()[1:]
+
+	logs := make([]*core.TransactionInfo_Log, 0)
+	for _, info := range infos {
+		for _, log := range info.GetLog() {
+			if !bytes.Equal(log.GetAddress(), address) {
+				continue
+			}
+			if !entry.Anonymous && (len(log.GetTopics()) == 0 || !bytes.Equal(log.GetTopics()[0], topic)) {
+				continue
+			}
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// Assign store an unpacked value into dst, converting generic lists
+// (like base58 address lists) into the typed destination
+func Assign(dst interface{}, value interface{}) error {
+	d := reflect.ValueOf(dst)
+	if d.Kind() != reflect.Ptr || d.IsNil() {
+		return fmt.Errorf("destination must be a non nil pointer")
+	}
+	return assign(d.Elem(), reflect.ValueOf(value))
+}
+
+func assign(d, s reflect.Value) error {
+	if !s.IsValid() {
+		d.Set(reflect.Zero(d.Type()))
+		return nil
+	}
+	if s.Kind() == reflect.Interface {
+		return assign(d, s.Elem())
+	}
+	if s.Type().AssignableTo(d.Type()) {
+		d.Set(s)
+		return nil
+	}
+	switch d.Kind() {
+	case reflect.Slice:
+		if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
+			break
+		}
+		list := reflect.MakeSlice(d.Type(), s.Len(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			if err := assign(list.Index(i), s.Index(i)); err != nil {
+				return err
+			}
+		}
+		d.Set(list)
+		return nil
+	case reflect.Array:
+		if (s.Kind() != reflect.Slice && s.Kind() != reflect.Array) || s.Len() != d.Len() {
+			break
+		}
+		for i := 0; i < s.Len(); i++ {
+			if err := assign(d.Index(i), s.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot assign %s to %s", s.Type(), d.Type())
+}
+
+// AssignResults store unpacked method outputs into dst, in order
+func AssignResults(values []interface{}, dst ...interface{}) error {
+	if len(values) != len(dst) {
+		return fmt.Errorf("invalid results length %d/%d", len(values), len(dst))
+	}
+	for i := range dst {
+		if err := Assign(dst[i], values[i]); err != nil {
+			return fmt.Errorf("result %d: %v", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"math/big"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenABI = `[
+{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"deposit","stateMutability":"payable","inputs":[{"name":"order","type":"tuple[]","components":[{"name":"id","type":"uint64"},{"name":"to","type":"address"}]}],"outputs":[]},
+{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]}
+]`
+
+func TestGenerate(t *testing.T) {
+	contractABI, err := abi.ParseJSON([]byte(tokenABI))
+	require.Nil(t, err)
+	assert.Equal(t, "(uint64 id,address to)[]", contractABI.Entrys[3].Inputs[0].Type)
+
+	code, err := Generate("token", "token", contractABI)
+	require.Nil(t, err)
+	typeCheck(t, code)
+
+	assert.Contains(t, code, "func (_Token *Token) BalanceOf(owner string) (result0 *big.Int, err error)")
+	assert.Contains(t, code, "func (_Token *Token) Transfer(opts *bind.TransactOpts, to string, amount *big.Int)")
+	assert.Contains(t, code, "func (_Token *Token) Transfer0(opts *bind.TransactOpts, to string, amount *big.Int, data []byte)")
+	assert.Contains(t, code, `"deposit((uint64,address)[])"`)
+	// tuples keep their component names so that maps can be passed
+	assert.Contains(t, code, `{"(uint64 id,address to)[]": order}`)
+	_, err = abi.Pack("deposit((uint64,address)[])", []abi.Param{
+		{"(uint64 id,address to)[]": []interface{}{map[string]interface{}{"id": 1, "to": "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"}}},
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, code, "type TokenTransfer struct")
+	assert.Contains(t, code, "func (_Token *Token) FilterTransfer(infos ...*core.TransactionInfo)")
+
+	// embedded ABI must parse back to the same entries
+	start := len("const TokenABI = ")
+	for _, line := range strings.Split(code, "\n") {
+		if len(line) > start && line[:start] == "const TokenABI = " {
+			embedded, err := strconv.Unquote(line[start:])
+			require.Nil(t, err)
+			parsed, err := abi.ParseJSON([]byte(embedded))
+			require.Nil(t, err)
+			require.Equal(t, len(contractABI.Entrys), len(parsed.Entrys))
+			for i := range parsed.Entrys {
+				assert.Equal(t, contractABI.Entrys[i].String(), parsed.Entrys[i].String())
+			}
+		}
+	}
+}
+
+// typeCheck type-checks the generated code in memory against the export data
+// of its imports, built by go list in the build cache
+func typeCheck(t *testing.T, code string) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "token.go", code, 0)
+	require.Nil(t, err)
+
+	args := []string{"list", "-export", "-deps", "-f", "{{.ImportPath}}={{.Export}}"}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		require.Nil(t, err)
+		args = append(args, path)
+	}
+	out, err := exec.Command(goBin, args...).Output()
+	require.Nil(t, err, string(out))
+	exports := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if i := strings.Index(line, "="); i > 0 {
+			exports[line[:i]] = line[i+1:]
+		}
+	}
+
+	lookup := func(path string) (io.ReadCloser, error) {
+		export, ok := exports[path]
+		if !ok || export == "" {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(export)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "gc", lookup)}
+	_, err = conf.Check("token", fset, []*ast.File{file}, nil)
+	require.Nil(t, err)
+}
+
+func TestBoundContract_FilterLogs(t *testing.T) {
+	contractABI, err := abi.ParseJSON([]byte(tokenABI))
+	require.Nil(t, err)
+
+	address := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	contractDesc, err := tron.Base58ToAddress(address)
+	require.Nil(t, err)
+	from := eCommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := eCommon.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	transfer := &core.TransactionInfo_Log{
+		Address: contractDesc.Bytes()[1:],
+		Topics: [][]byte{
+			abi.EventTopic("Transfer(address,address,uint256)"),
+			eCommon.BytesToHash(from.Bytes()).Bytes(),
+			eCommon.BytesToHash(to.Bytes()).Bytes(),
+		},
+		Data: eCommon.BigToHash(big.NewInt(1000)).Bytes(),
+	}
+	other := &core.TransactionInfo_Log{
+		Address: make([]byte, 20),
+		Topics:  transfer.Topics,
+		Data:    transfer.Data,
+	}
+
+	b := NewBoundContract(address, contractABI, nil)
+	logs, err := b.FilterLogs("Transfer", &core.TransactionInfo{Log: []*core.TransactionInfo_Log{other, transfer}})
+	require.Nil(t, err)
+	require.Len(t, logs, 1)
+
+	values, err := b.UnpackLog("Transfer", logs[0])
+	require.Nil(t, err)
+	var value *big.Int
+	var sender string
+	require.Nil(t, Assign(&value, values["value"]))
+	require.Nil(t, Assign(&sender, values["from"]))
+	assert.Equal(t, int64(1000), value.Int64())
+	assert.Equal(t, tron.Address(append([]byte{tron.TronBytePrefix}, from.Bytes()...)).String(), sender)
+}
+
+func TestAssign(t *testing.T) {
+	var owners []string
+	require.Nil(t, Assign(&owners, []interface{}{"TA", "TB"}))
+	assert.Equal(t, []string{"TA", "TB"}, owners)
+
+	var fixed [2]string
+	require.Nil(t, Assign(&fixed, []interface{}{"TA", "TB"}))
+	assert.Equal(t, [2]string{"TA", "TB"}, fixed)
+
+	var hash [32]byte
+	require.Nil(t, Assign(&hash, eCommon.Hash{1}))
+	assert.Equal(t, byte(1), hash[0])
+
+	var n uint8
+	assert.NotNil(t, Assign(&n, "1"))
+	assert.NotNil(t, AssignResults([]interface{}{uint8(1)}, &n, &hash))
+}
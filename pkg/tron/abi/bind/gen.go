@@ -0,0 +1,361 @@
+package bind
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// reserved identifiers used by imports and inside generated method bodies
+var reservedNames = map[string]bool{
+	"big": true, "abi": true, "bind": true, "client": true, "api": true, "core": true,
+	"opts": true, "out": true, "err": true, "logs": true, "log": true,
+	"values": true, "event": true, "events": true, "infos": true,
+}
+
+type tmplArg struct {
+	Name    string // Go identifier
+	Key     string // key in decoded values
+	Type    string // ABI type, tuples with their component names
+	GoType  string
+	Indexed bool
+}
+
+type tmplMethod struct {
+	Name      string
+	Signature string
+	Selector  string
+	Solidity  string
+	Inputs    []tmplArg
+	Outputs   []tmplArg
+}
+
+type tmplEvent struct {
+	Name      string
+	Signature string
+	Solidity  string
+	Fields    []tmplArg
+}
+
+type tmplData struct {
+	Package   string
+	Type      string
+	ABI       string
+	Calls     []tmplMethod
+	Transacts []tmplMethod
+	Events    []tmplEvent
+}
+
+// Generate returns the Go source of a typed binding for the contract ABI:
+// constant methods are bound to constant calls, the others to transaction
+// builders and events to typed log decoders
+func Generate(pkg, typeName string, contractABI *core.SmartContract_ABI) (string, error) {
+	if contractABI == nil {
+		return "", fmt.Errorf("invalid contract abi")
+	}
+	if !token.IsIdentifier(pkg) {
+		return "", fmt.Errorf("invalid package name %s", pkg)
+	}
+	typeName = capitalise(typeName)
+	if !token.IsIdentifier(typeName) {
+		return "", fmt.Errorf("invalid type name %s", typeName)
+	}
+
+	encoded, err := marshalABI(contractABI)
+	if err != nil {
+		return "", err
+	}
+	data := &tmplData{
+		Package: pkg,
+		Type:    typeName,
+		ABI:     encoded,
+	}
+
+	used := map[string]bool{"Contract": true}
+	usedEvents := make(map[string]bool)
+	for _, entry := range contractABI.Entrys {
+		switch entry.Type {
+		case core.SmartContract_ABI_Entry_Function:
+			method, err := newMethod(entry, used)
+			if err != nil {
+				return "", err
+			}
+			if isConstant(entry) {
+				data.Calls = append(data.Calls, method)
+			} else {
+				data.Transacts = append(data.Transacts, method)
+			}
+		case core.SmartContract_ABI_Entry_Event:
+			event, err := newEvent(entry, usedEvents, used)
+			if err != nil {
+				return "", err
+			}
+			data.Events = append(data.Events, event)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := bindTemplate.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, buffer)
+	}
+	return string(code), nil
+}
+
+// marshalABI encode the ABI in the node JSON form accepted by abi.ParseJSON
+func marshalABI(contractABI *core.SmartContract_ABI) (string, error) {
+	type param struct {
+		Indexed bool   `json:"indexed,omitempty"`
+		Name    string `json:"name,omitempty"`
+		Type    string `json:"type"`
+	}
+	type entry struct {
+		Anonymous       bool    `json:"anonymous,omitempty"`
+		Constant        bool    `json:"constant,omitempty"`
+		Name            string  `json:"name,omitempty"`
+		Inputs          []param `json:"inputs,omitempty"`
+		Outputs         []param `json:"outputs,omitempty"`
+		Type            string  `json:"type"`
+		Payable         bool    `json:"payable,omitempty"`
+		StateMutability string  `json:"stateMutability,omitempty"`
+	}
+	toParams := func(params []*core.SmartContract_ABI_Entry_Param) []param {
+		result := make([]param, 0, len(params))
+		for _, p := range params {
+			result = append(result, param{Indexed: p.Indexed, Name: p.Name, Type: p.Type})
+		}
+		return result
+	}
+
+	entries := make([]entry, 0, len(contractABI.Entrys))
+	for _, e := range contractABI.Entrys {
+		item := entry{
+			Anonymous: e.Anonymous,
+			Constant:  e.Constant,
+			Name:      e.Name,
+			Inputs:    toParams(e.Inputs),
+			Outputs:   toParams(e.Outputs),
+			Type:      e.Type.String(),
+			Payable:   e.Payable,
+		}
+		if e.StateMutability != core.SmartContract_ABI_Entry_UnknownMutabilityType {
+			item.StateMutability = e.StateMutability.String()
+		}
+		entries = append(entries, item)
+	}
+	b, err := json.Marshal(map[string]interface{}{"entrys": entries})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func isConstant(entry *core.SmartContract_ABI_Entry) bool {
+	return entry.Constant ||
+		entry.StateMutability == core.SmartContract_ABI_Entry_View ||
+		entry.StateMutability == core.SmartContract_ABI_Entry_Pure
+}
+
+func newMethod(entry *core.SmartContract_ABI_Entry, used map[string]bool) (tmplMethod, error) {
+	signature, err := abi.EntrySignature(entry)
+	if err != nil {
+		return tmplMethod{}, err
+	}
+	inputs, err := newArgs(entry.Inputs, nil, "arg")
+	if err != nil {
+		return tmplMethod{}, err
+	}
+	taken := make(map[string]bool)
+	for _, in := range inputs {
+		taken[in.Name] = true
+	}
+	outputs, err := newArgs(entry.Outputs, taken, "result")
+	if err != nil {
+		return tmplMethod{}, err
+	}
+	return tmplMethod{
+		Name:      uniqueName(capitalise(entry.Name), used),
+		Signature: signature,
+		Selector:  fmt.Sprintf("0x%x", abi.Signature(signature)),
+		Solidity:  solidity("function", entry),
+		Inputs:    inputs,
+		Outputs:   outputs,
+	}, nil
+}
+
+func newEvent(entry *core.SmartContract_ABI_Entry, usedEvents, used map[string]bool) (tmplEvent, error) {
+	signature, err := abi.EntrySignature(entry)
+	if err != nil {
+		return tmplEvent{}, err
+	}
+	arguments, err := abi.NewArguments(entry.Inputs)
+	if err != nil {
+		return tmplEvent{}, err
+	}
+	name := uniqueName(capitalise(entry.Name), usedEvents)
+	used["Parse"+name] = true
+	used["Filter"+name] = true
+
+	fields := make([]tmplArg, len(arguments))
+	taken := map[string]bool{"Raw": true}
+	for i, arg := range arguments {
+		key := arg.Name
+		if len(key) == 0 {
+			key = fmt.Sprintf("arg%d", i)
+		}
+		fieldType := goType(arg.Type)
+		if arg.Indexed && isHashedTopic(arg.Type) {
+			// only the keccak256 hash of indexed dynamic values is logged
+			fieldType = "[32]byte"
+		}
+		fields[i] = tmplArg{
+			Name:    uniqueName(capitalise(key), taken),
+			Key:     key,
+			Type:    entry.Inputs[i].Type,
+			GoType:  fieldType,
+			Indexed: arg.Indexed,
+		}
+	}
+	return tmplEvent{
+		Name:      name,
+		Signature: signature,
+		Solidity:  solidity("event", entry),
+		Fields:    fields,
+	}, nil
+}
+
+func newArgs(params []*core.SmartContract_ABI_Entry_Param, taken map[string]bool, prefix string) ([]tmplArg, error) {
+	arguments, err := abi.NewArguments(params)
+	if err != nil {
+		return nil, err
+	}
+	if taken == nil {
+		taken = make(map[string]bool)
+	}
+	args := make([]tmplArg, len(arguments))
+	for i, arg := range arguments {
+		name := decapitalise(arg.Name)
+		if len(name) == 0 || !token.IsIdentifier(name) || token.IsKeyword(name) || reservedNames[name] || taken[name] {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		taken[name] = true
+		args[i] = tmplArg{
+			Name:   name,
+			Key:    arg.Name,
+			Type:   params[i].Type,
+			GoType: goType(arg.Type),
+		}
+	}
+	return args, nil
+}
+
+// goType returns the Go type used by the binding for an ABI type, addresses are
+// base58 strings and tuples are passed as generic values (map or list)
+func goType(ty eABI.Type) string {
+	switch ty.T {
+	case eABI.AddressTy, eABI.StringTy:
+		return "string"
+	case eABI.IntTy, eABI.UintTy:
+		prefix := "int"
+		if ty.T == eABI.UintTy {
+			prefix = "uint"
+		}
+		switch ty.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, ty.Size)
+		}
+		return "*big.Int"
+	case eABI.BoolTy:
+		return "bool"
+	case eABI.BytesTy:
+		return "[]byte"
+	case eABI.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", ty.Size)
+	case eABI.FunctionTy:
+		return "[24]byte"
+	case eABI.SliceTy:
+		return "[]" + goType(*ty.Elem)
+	case eABI.ArrayTy:
+		return fmt.Sprintf("[%d]%s", ty.Size, goType(*ty.Elem))
+	}
+	return "interface{}"
+}
+
+func isHashedTopic(ty eABI.Type) bool {
+	switch ty.T {
+	case eABI.StringTy, eABI.BytesTy, eABI.SliceTy, eABI.ArrayTy, eABI.TupleTy:
+		return true
+	}
+	return false
+}
+
+// solidity returns a human readable declaration for doc comments
+func solidity(kind string, entry *core.SmartContract_ABI_Entry) string {
+	params := func(list []*core.SmartContract_ABI_Entry_Param) string {
+		fields := make([]string, len(list))
+		for i, p := range list {
+			field := p.Type
+			if p.Indexed {
+				field += " indexed"
+			}
+			fields[i] = strings.TrimSpace(field + " " + p.Name)
+		}
+		return strings.Join(fields, ", ")
+	}
+	declaration := fmt.Sprintf("%s %s(%s)", kind, entry.Name, params(entry.Inputs))
+	if kind == "event" {
+		if entry.Anonymous {
+			declaration += " anonymous"
+		}
+		return declaration
+	}
+	if entry.StateMutability != core.SmartContract_ABI_Entry_UnknownMutabilityType &&
+		entry.StateMutability != core.SmartContract_ABI_Entry_Nonpayable {
+		declaration += " " + strings.ToLower(entry.StateMutability.String())
+	}
+	if len(entry.Outputs) > 0 {
+		declaration += fmt.Sprintf(" returns(%s)", params(entry.Outputs))
+	}
+	return declaration
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 0; used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// capitalise makes a camel-case identifier exported, leading underscores are dropped
+func capitalise(name string) string {
+	name = strings.TrimLeft(name, "_")
+	if len(name) == 0 {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func decapitalise(name string) string {
+	name = strings.TrimLeft(name, "_")
+	if len(name) == 0 {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
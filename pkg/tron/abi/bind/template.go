@@ -0,0 +1,113 @@
+package bind
+
+import "text/template"
+
+var bindTemplate = template.Must(template.New("bind").Parse(tmplSource))
+
+const tmplSource = `// Code generated by tronabigen - DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"math/big"
+
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/abi/bind"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = bind.Assign
+	_ = api.TransactionExtention{}
+	_ = core.TransactionInfo{}
+)
+
+// {{.Type}}ABI is the input ABI used to generate the binding from.
+const {{.Type}}ABI = {{printf "%q" .ABI}}
+
+// {{.Type}} is a binding around a TRON contract.
+type {{.Type}} struct {
+	contract *bind.BoundContract
+}
+
+// New{{.Type}} creates a new instance of {{.Type}}, bound to a deployed contract.
+func New{{.Type}}(address string, c *client.GrpcClient) (*{{.Type}}, error) {
+	contractABI, err := abi.ParseJSON([]byte({{.Type}}ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{contract: bind.NewBoundContract(address, contractABI, c)}, nil
+}
+
+// Contract returns the underlying bound contract.
+func (_{{$.Type}} *{{.Type}}) Contract() *bind.BoundContract {
+	return _{{$.Type}}.contract
+}
+{{range .Calls}}
+// {{.Name}} is a constant call binding the contract method {{.Selector}}.
+//
+// Solidity: {{.Solidity}}
+func (_{{$.Type}} *{{$.Type}}) {{.Name}}({{range $i, $in := .Inputs}}{{if $i}}, {{end}}{{$in.Name}} {{$in.GoType}}{{end}}) ({{range .Outputs}}{{.Name}} {{.GoType}}, {{end}}err error) {
+	out, err := _{{$.Type}}.contract.Call({{printf "%q" .Signature}}, []abi.Param{ {{- range .Inputs}}
+		{ {{- printf "%q" .Type}}: {{.Name -}} },{{end}}
+	})
+	if err != nil {
+		return
+	}
+	err = bind.AssignResults(out{{range .Outputs}}, &{{.Name}}{{end}})
+	return
+}
+{{end}}{{range .Transacts}}
+// {{.Name}} is a transaction builder binding the contract method {{.Selector}}.
+//
+// Solidity: {{.Solidity}}
+func (_{{$.Type}} *{{$.Type}}) {{.Name}}(opts *bind.TransactOpts{{range .Inputs}}, {{.Name}} {{.GoType}}{{end}}) (*api.TransactionExtention, error) {
+	return _{{$.Type}}.contract.Transact(opts, {{printf "%q" .Signature}}, []abi.Param{ {{- range .Inputs}}
+		{ {{- printf "%q" .Type}}: {{.Name -}} },{{end}}
+	})
+}
+{{end}}{{range .Events}}
+// {{$.Type}}{{.Name}} represents a {{.Name}} event raised by the {{$.Type}} contract.
+type {{$.Type}}{{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.GoType}}{{end}}
+	Raw *core.TransactionInfo_Log
+}
+
+// Parse{{.Name}} decodes a {{.Name}} event log.
+//
+// Solidity: {{.Solidity}}
+func (_{{$.Type}} *{{$.Type}}) Parse{{.Name}}(log *core.TransactionInfo_Log) (*{{$.Type}}{{.Name}}, error) {
+	values, err := _{{$.Type}}.contract.UnpackLog({{printf "%q" .Signature}}, log)
+	if err != nil {
+		return nil, err
+	}
+	event := &{{$.Type}}{{.Name}}{Raw: log}
+{{- range .Fields}}
+	if err := bind.Assign(&event.{{.Name}}, values[{{printf "%q" .Key}}]); err != nil {
+		return nil, err
+	}{{end}}
+	return event, nil
+}
+
+// Filter{{.Name}} returns the {{.Name}} events emitted by the contract in the given transactions.
+func (_{{$.Type}} *{{$.Type}}) Filter{{.Name}}(infos ...*core.TransactionInfo) ([]*{{$.Type}}{{.Name}}, error) {
+	logs, err := _{{$.Type}}.contract.FilterLogs({{printf "%q" .Signature}}, infos...)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*{{$.Type}}{{.Name}}, 0, len(logs))
+	for _, log := range logs {
+		event, err := _{{$.Type}}.Parse{{.Name}}(log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+{{end}}`
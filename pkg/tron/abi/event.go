@@ -0,0 +1,111 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/sha3"
+)
+
+// EventTopic returns the keccak256 hash of an event signature, e.g. Transfer(address,address,uint256)
+func EventTopic(signature string) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(signature))
+	return hasher.Sum(nil)
+}
+
+// GetEventEntry find an event entry by name or by full signature (name(type,...))
+func GetEventEntry(ABI *core.SmartContract_ABI, event string) (*core.SmartContract_ABI_Entry, error) {
+	if ABI == nil {
+		return nil, fmt.Errorf("invalid contract abi")
+	}
+	byName := !strings.Contains(event, "(")
+	for _, entry := range ABI.Entrys {
+		if entry.Type != core.SmartContract_ABI_Entry_Event {
+			continue
+		}
+		if byName {
+			if entry.Name == event {
+				return entry, nil
+			}
+			continue
+		}
+		signature, err := EntrySignature(entry)
+		if err != nil {
+			return nil, err
+		}
+		if signature == strings.ReplaceAll(event, " ", "") {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("event %s not found", event)
+}
+
+// eventArguments return event params, unnamed params are called argN
+func eventArguments(entry *core.SmartContract_ABI_Entry) (eABI.Arguments, error) {
+	arguments, err := NewArguments(entry.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range arguments {
+		if len(arguments[i].Name) == 0 {
+			arguments[i].Name = fmt.Sprintf("arg%d", i)
+		}
+	}
+	return arguments, nil
+}
+
+// UnpackLog decode an event log into a map keyed by param name, addresses are
+// returned in base58. Indexed dynamic values (strings, bytes, arrays and tuples)
+// can only be recovered as their topic hash.
+func UnpackLog(entry *core.SmartContract_ABI_Entry, log *core.TransactionInfo_Log) (map[string]interface{}, error) {
+	arguments, err := eventArguments(entry)
+	if err != nil {
+		return nil, err
+	}
+	topics := log.GetTopics()
+	if !entry.Anonymous {
+		signature, err := EntrySignature(entry)
+		if err != nil {
+			return nil, err
+		}
+		if len(topics) == 0 || !bytes.Equal(topics[0], EventTopic(signature)) {
+			return nil, fmt.Errorf("log is not a %s event", signature)
+		}
+		topics = topics[1:]
+	}
+
+	result := make(map[string]interface{})
+	if err := arguments.NonIndexed().UnpackIntoMap(result, log.GetData()); err != nil {
+		return nil, err
+	}
+
+	indexed := make(eABI.Arguments, 0)
+	for _, arg := range arguments {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(indexed) != len(topics) {
+		return nil, fmt.Errorf("invalid topics length %d/%d", len(topics), len(indexed))
+	}
+	for i, arg := range indexed {
+		hash := eCommon.BytesToHash(topics[i])
+		if arg.Type.T == eABI.TupleTy {
+			result[arg.Name] = hash
+			continue
+		}
+		if err := eABI.ParseTopicsIntoMap(result, eABI.Arguments{arg}, []eCommon.Hash{hash}); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, value := range result {
+		result[name] = ConvertAddresses([]interface{}{value})[0]
+	}
+	return result, nil
+}
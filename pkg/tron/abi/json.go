@@ -0,0 +1,118 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+)
+
+// jsonParam solidity ABI param, tuple components are folded into the type string
+type jsonParam struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Indexed    bool        `json:"indexed"`
+	Components []jsonParam `json:"components"`
+}
+
+type jsonEntry struct {
+	Anonymous       bool        `json:"anonymous"`
+	Constant        bool        `json:"constant"`
+	Name            string      `json:"name"`
+	Inputs          []jsonParam `json:"inputs"`
+	Outputs         []jsonParam `json:"outputs"`
+	Type            string      `json:"type"`
+	Payable         bool        `json:"payable"`
+	StateMutability string      `json:"stateMutability"`
+}
+
+// ParseJSON parse a contract ABI from its JSON form, either a solidity compiler
+// ABI array or the TRON node representation ({"entrys": [...]})
+func ParseJSON(data []byte) (*core.SmartContract_ABI, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty abi")
+	}
+
+	entries := make([]jsonEntry, 0)
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+	} else {
+		wrapper := struct {
+			Entrys []jsonEntry `json:"entrys"`
+			ABI    []jsonEntry `json:"abi"`
+		}{}
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return nil, err
+		}
+		entries = append(wrapper.Entrys, wrapper.ABI...)
+	}
+
+	contractABI := &core.SmartContract_ABI{}
+	for _, e := range entries {
+		entryType, ok := lookupEnum(core.SmartContract_ABI_Entry_EntryType_value, e.Type)
+		if !ok {
+			return nil, fmt.Errorf("invalid abi entry type %s", e.Type)
+		}
+		entry := &core.SmartContract_ABI_Entry{
+			Anonymous: e.Anonymous,
+			Constant:  e.Constant,
+			Name:      e.Name,
+			Type:      core.SmartContract_ABI_Entry_EntryType(entryType),
+			Payable:   e.Payable,
+		}
+		if len(e.StateMutability) > 0 {
+			mutability, ok := lookupEnum(core.SmartContract_ABI_Entry_StateMutabilityType_value, e.StateMutability)
+			if !ok {
+				return nil, fmt.Errorf("invalid state mutability %s", e.StateMutability)
+			}
+			entry.StateMutability = core.SmartContract_ABI_Entry_StateMutabilityType(mutability)
+		}
+		entry.Inputs = toEntryParams(e.Inputs)
+		entry.Outputs = toEntryParams(e.Outputs)
+		contractABI.Entrys = append(contractABI.Entrys, entry)
+	}
+	return contractABI, nil
+}
+
+// lookupEnum match proto enum names case insensitively (solidity uses lower case)
+func lookupEnum(values map[string]int32, name string) (int32, bool) {
+	if len(name) == 0 {
+		// solidity defaults to function
+		name = "function"
+	}
+	for k, v := range values {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func toEntryParams(params []jsonParam) []*core.SmartContract_ABI_Entry_Param {
+	result := make([]*core.SmartContract_ABI_Entry_Param, 0, len(params))
+	for _, p := range params {
+		result = append(result, &core.SmartContract_ABI_Entry_Param{
+			Indexed: p.Indexed,
+			Name:    p.Name,
+			Type:    paramType(p),
+		})
+	}
+	return result
+}
+
+// paramType returns the param type, tuples are written as "(type name,...)[suffix]"
+func paramType(p jsonParam) string {
+	if !strings.HasPrefix(p.Type, "tuple") || len(p.Components) == 0 {
+		return p.Type
+	}
+	fields := make([]string, len(p.Components))
+	for i, c := range p.Components {
+		fields[i] = strings.TrimSpace(paramType(c) + " " + c.Name)
+	}
+	return "(" + strings.Join(fields, ",") + ")" + strings.TrimPrefix(p.Type, "tuple")
+}
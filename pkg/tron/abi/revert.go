@@ -0,0 +1,73 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	revertSelector = Signature("Error(string)")
+	panicSelector  = Signature("Panic(uint256)")
+)
+
+// panicReasons from solidity documentation
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized internal function",
+}
+
+// RevertError returned by contracts using revert/require with a reason
+type RevertError struct {
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("execution reverted: %s", e.Reason)
+}
+
+// PanicError returned by contracts on failed assertions and runtime checks
+type PanicError struct {
+	Code *big.Int
+}
+
+func (e *PanicError) Error() string {
+	if e.Code.IsUint64() {
+		if reason, ok := panicReasons[e.Code.Uint64()]; ok {
+			return fmt.Sprintf("execution panicked (0x%02x): %s", e.Code.Uint64(), reason)
+		}
+	}
+	return fmt.Sprintf("execution panicked: 0x%x", e.Code)
+}
+
+// UnpackRevert decode revert data into RevertError or PanicError, nil is returned
+// when data is not a known revert encoding
+func UnpackRevert(data []byte) error {
+	if len(data) < 4 {
+		return nil
+	}
+	switch {
+	case bytes.Equal(data[:4], revertSelector):
+		reason, err := eABI.UnpackRevert(data)
+		if err != nil {
+			return nil
+		}
+		return &RevertError{Reason: reason}
+	case bytes.Equal(data[:4], panicSelector):
+		if len(data) != 4+32 {
+			return nil
+		}
+		return &PanicError{Code: new(big.Int).SetBytes(data[4:])}
+	}
+	return nil
+}
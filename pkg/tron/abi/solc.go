@@ -0,0 +1,231 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"golang.org/x/crypto/sha3"
+)
+
+// SolcContract compiled contract from solc output, Bytecode may hold library
+// placeholders until linked
+type SolcContract struct {
+	Name            string // file:Name
+	ABI             *core.SmartContract_ABI
+	Bytecode        string // hex, without 0x
+	RuntimeBytecode string // hex, without 0x
+	// ImmutableReferences runtime code ranges written by the constructor,
+	// only reported by the standard json output
+	ImmutableReferences []ImmutableReference
+}
+
+// ImmutableReference byte range of an immutable variable in the runtime code
+type ImmutableReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// parseSolcABI accept the ABI either as JSON array or as a JSON encoded string
+// (older solc combined-json)
+func parseSolcABI(data json.RawMessage) (*core.SmartContract_ABI, error) {
+	if len(data) == 0 {
+		return &core.SmartContract_ABI{}, nil
+	}
+	if data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return nil, err
+		}
+		data = json.RawMessage(text)
+	}
+	return ParseJSON(data)
+}
+
+// ParseSolcCombinedJSON parse the output of solc --combined-json abi,bin[,bin-runtime],
+// contracts are keyed by file:Name
+func ParseSolcCombinedJSON(data []byte) (map[string]*SolcContract, error) {
+	output := struct {
+		Contracts map[string]struct {
+			ABI        json.RawMessage `json:"abi"`
+			Bin        string          `json:"bin"`
+			BinRuntime string          `json:"bin-runtime"`
+		} `json:"contracts"`
+	}{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("invalid solc combined json: %v", err)
+	}
+
+	contracts := make(map[string]*SolcContract, len(output.Contracts))
+	for name, c := range output.Contracts {
+		contractABI, err := parseSolcABI(c.ABI)
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %v", name, err)
+		}
+		contracts[name] = &SolcContract{
+			Name:            name,
+			ABI:             contractABI,
+			Bytecode:        strings.TrimPrefix(c.Bin, "0x"),
+			RuntimeBytecode: strings.TrimPrefix(c.BinRuntime, "0x"),
+		}
+	}
+	return contracts, nil
+}
+
+// ParseSolcStandardJSON parse the output of solc --standard-json, contracts
+// are keyed by file:Name, compilation errors are returned as error
+func ParseSolcStandardJSON(data []byte) (map[string]*SolcContract, error) {
+	output := struct {
+		Errors []struct {
+			Severity         string `json:"severity"`
+			FormattedMessage string `json:"formattedMessage"`
+			Message          string `json:"message"`
+		} `json:"errors"`
+		Contracts map[string]map[string]struct {
+			ABI json.RawMessage `json:"abi"`
+			EVM struct {
+				Bytecode struct {
+					Object string `json:"object"`
+				} `json:"bytecode"`
+				DeployedBytecode struct {
+					Object              string                          `json:"object"`
+					ImmutableReferences map[string][]ImmutableReference `json:"immutableReferences"`
+				} `json:"deployedBytecode"`
+			} `json:"evm"`
+		} `json:"contracts"`
+	}{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("invalid solc standard json: %v", err)
+	}
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			if e.FormattedMessage != "" {
+				return nil, fmt.Errorf("solc: %s", e.FormattedMessage)
+			}
+			return nil, fmt.Errorf("solc: %s", e.Message)
+		}
+	}
+
+	contracts := make(map[string]*SolcContract)
+	for file, fileContracts := range output.Contracts {
+		for contractName, c := range fileContracts {
+			name := file + ":" + contractName
+			contractABI, err := parseSolcABI(c.ABI)
+			if err != nil {
+				return nil, fmt.Errorf("contract %s: %v", name, err)
+			}
+			immutables := make([]ImmutableReference, 0)
+			for _, references := range c.EVM.DeployedBytecode.ImmutableReferences {
+				immutables = append(immutables, references...)
+			}
+			sort.Slice(immutables, func(i, j int) bool { return immutables[i].Start < immutables[j].Start })
+			contracts[name] = &SolcContract{
+				Name:                name,
+				ABI:                 contractABI,
+				Bytecode:            strings.TrimPrefix(c.EVM.Bytecode.Object, "0x"),
+				RuntimeBytecode:     strings.TrimPrefix(c.EVM.DeployedBytecode.Object, "0x"),
+				ImmutableReferences: immutables,
+			}
+		}
+	}
+	return contracts, nil
+}
+
+// FindSolcContract returns a contract by file:Name or by Name when not ambiguous
+func FindSolcContract(contracts map[string]*SolcContract, name string) (*SolcContract, error) {
+	if c, ok := contracts[name]; ok {
+		return c, nil
+	}
+	var found *SolcContract
+	for fullName, c := range contracts {
+		if fullName[strings.LastIndex(fullName, ":")+1:] == name {
+			if found != nil {
+				return nil, fmt.Errorf("contract name %s is ambiguous", name)
+			}
+			found = c
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("contract %s not found", name)
+	}
+	return found, nil
+}
+
+// libraryPlaceholders returns the placeholders solc may emit for a library:
+// __$<hash>$__ since 0.5 and the name padded with '_' before
+func libraryPlaceholders(library string) []string {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(library))
+	placeholders := []string{"__$" + hex.EncodeToString(hasher.Sum(nil))[:34] + "$__"}
+
+	legacy := library
+	if len(legacy) > 36 {
+		legacy = legacy[:36]
+	}
+	legacy = "__" + legacy
+	placeholders = append(placeholders, legacy+strings.Repeat("_", 40-len(legacy)))
+	return placeholders
+}
+
+// LinkBytecode replace library placeholders with the base58 library addresses,
+// libraries are named file:Name (or Name for legacy placeholders)
+func LinkBytecode(bytecode string, libraries map[string]string) (string, error) {
+	names := make([]string, 0, len(libraries))
+	for name := range libraries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		address, err := tron.Base58ToAddress(libraries[name])
+		if err != nil {
+			return "", fmt.Errorf("library %s: invalid address %s: %v", name, libraries[name], err)
+		}
+		linked := hex.EncodeToString(address.Bytes()[len(address.Bytes())-20:])
+		for _, placeholder := range libraryPlaceholders(name) {
+			bytecode = strings.ReplaceAll(bytecode, placeholder, linked)
+		}
+	}
+	if i := strings.Index(bytecode, "__"); i >= 0 {
+		end := i + 40
+		if end > len(bytecode) {
+			end = len(bytecode)
+		}
+		return "", fmt.Errorf("unlinked library placeholder %s", bytecode[i:end])
+	}
+	return bytecode, nil
+}
+
+// GetConstructorEntry returns the constructor of an ABI, nil when it has none
+func GetConstructorEntry(ABI *core.SmartContract_ABI) *core.SmartContract_ABI_Entry {
+	for _, entry := range ABI.GetEntrys() {
+		if entry.GetType() == core.SmartContract_ABI_Entry_Constructor {
+			return entry
+		}
+	}
+	return nil
+}
+
+// PackConstructor encode constructor arguments, checked against the ABI constructor
+func PackConstructor(ABI *core.SmartContract_ABI, param []Param) ([]byte, error) {
+	entry := GetConstructorEntry(ABI)
+	inputs := entry.GetInputs()
+	if len(inputs) != len(param) {
+		return nil, fmt.Errorf("constructor expects %d arguments, got %d", len(inputs), len(param))
+	}
+	for i, p := range param {
+		for k := range p {
+			if k != inputs[i].GetType() {
+				return nil, fmt.Errorf("constructor argument %d: expected %s, got %s", i, inputs[i].GetType(), k)
+			}
+		}
+	}
+	if len(param) == 0 {
+		return nil, nil
+	}
+	return GetPaddedParam(param)
+}
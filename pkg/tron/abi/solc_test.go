@@ -0,0 +1,89 @@
+package abi
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+const solcConstructorABI = `[{"inputs":[{"name":"owner","type":"address"},{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable","type":"constructor"}]`
+
+func TestParseSolcCombinedJSON(t *testing.T) {
+	// older solc encode the ABI as a string
+	abiString := `"` + strings.ReplaceAll(solcConstructorABI, `"`, `\"`) + `"`
+	data := `{"contracts":{"token.sol:Token":{"abi":` + abiString +
+		`,"bin":"6080","bin-runtime":"6081"},"lib.sol:Math":{"abi":[],"bin":"6082"}},"version":"0.8.6"}`
+	contracts, err := ParseSolcCombinedJSON([]byte(data))
+	require.Nil(t, err)
+	require.Len(t, contracts, 2)
+
+	token, err := FindSolcContract(contracts, "Token")
+	require.Nil(t, err)
+	assert.Equal(t, "token.sol:Token", token.Name)
+	assert.Equal(t, "6080", token.Bytecode)
+	assert.Equal(t, "6081", token.RuntimeBytecode)
+	require.NotNil(t, GetConstructorEntry(token.ABI))
+	assert.Len(t, GetConstructorEntry(token.ABI).GetInputs(), 2)
+
+	_, err = FindSolcContract(contracts, "Missing")
+	assert.NotNil(t, err)
+}
+
+func TestParseSolcStandardJSON(t *testing.T) {
+	data := `{"contracts":{"token.sol":{"Token":{"abi":` + solcConstructorABI +
+		`,"evm":{"bytecode":{"object":"6080"},"deployedBytecode":{"object":"6081"}}}}}}`
+	contracts, err := ParseSolcStandardJSON([]byte(data))
+	require.Nil(t, err)
+	require.Contains(t, contracts, "token.sol:Token")
+	assert.Equal(t, "6080", contracts["token.sol:Token"].Bytecode)
+
+	_, err = ParseSolcStandardJSON([]byte(`{"errors":[{"severity":"error","formattedMessage":"ParserError"}]}`))
+	assert.NotNil(t, err)
+}
+
+func TestLinkBytecode(t *testing.T) {
+	library := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	address, err := tron.Base58ToAddress(library)
+	require.Nil(t, err)
+	linked := hex.EncodeToString(address.Bytes()[1:])
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte("lib.sol:Math"))
+	placeholder := "__$" + hex.EncodeToString(hasher.Sum(nil))[:34] + "$__"
+
+	code, err := LinkBytecode("60"+placeholder+"60", map[string]string{"lib.sol:Math": library})
+	require.Nil(t, err)
+	assert.Equal(t, "60"+linked+"60", code)
+
+	legacy := "__Math" + strings.Repeat("_", 34)
+	code, err = LinkBytecode("60"+legacy, map[string]string{"Math": library})
+	require.Nil(t, err)
+	assert.Equal(t, "60"+linked, code)
+
+	_, err = LinkBytecode("60"+placeholder, nil)
+	assert.NotNil(t, err)
+}
+
+func TestPackConstructor(t *testing.T) {
+	contractABI, err := ParseJSON([]byte(solcConstructorABI))
+	require.Nil(t, err)
+
+	args, err := PackConstructor(contractABI, []Param{
+		{"address": "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+		{"uint256": "1000"},
+	})
+	require.Nil(t, err)
+	assert.Len(t, args, 64)
+	assert.Equal(t, byte(0x03), args[62])
+	assert.Equal(t, byte(0xe8), args[63])
+
+	_, err = PackConstructor(contractABI, []Param{{"address": "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"}})
+	assert.NotNil(t, err)
+	_, err = PackConstructor(contractABI, []Param{{"uint256": "1"}, {"uint256": "1"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,109 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// NewType parse a solidity type, tuples are accepted in the canonical
+// form "(address,uint256)" or "tuple(address to,uint256 amount)", optionally
+// followed by array dimensions and with any level of nesting
+func NewType(t string) (eABI.Type, error) {
+	t = strings.TrimSpace(t)
+	if !isTupleType(t) {
+		return eABI.NewType(t, "", nil)
+	}
+	components, suffix, err := parseTuple(t)
+	if err != nil {
+		return eABI.Type{}, err
+	}
+	return eABI.NewType("tuple"+suffix, "", components)
+}
+
+func isTupleType(t string) bool {
+	return strings.HasPrefix(t, "(") || strings.HasPrefix(t, "tuple(")
+}
+
+// parseTuple split a tuple type into its components and array suffix
+func parseTuple(t string) ([]eABI.ArgumentMarshaling, string, error) {
+	t = strings.TrimPrefix(t, "tuple")
+	depth := 0
+	end := -1
+	for i, c := range t {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && end < 0 {
+				end = i
+			}
+		}
+	}
+	if depth != 0 || end < 0 {
+		return nil, "", fmt.Errorf("invalid tuple type %s", t)
+	}
+	suffix := strings.TrimSpace(t[end+1:])
+	if len(suffix) > 0 && !strings.HasPrefix(suffix, "[") {
+		return nil, "", fmt.Errorf("invalid tuple type %s", t)
+	}
+
+	components := make([]eABI.ArgumentMarshaling, 0)
+	for i, field := range splitTopLevel(t[1:end]) {
+		component, err := parseComponent(field, i)
+		if err != nil {
+			return nil, "", err
+		}
+		components = append(components, component)
+	}
+	if len(components) == 0 {
+		return nil, "", fmt.Errorf("empty tuple type %s", t)
+	}
+	return components, suffix, nil
+}
+
+// parseComponent convert "type [name]" into argument marshaling
+func parseComponent(field string, index int) (eABI.ArgumentMarshaling, error) {
+	field = strings.TrimSpace(field)
+	name := fmt.Sprintf("field%d", index)
+
+	// name follows the last closing parenthesis or bracket
+	split := strings.LastIndexAny(field, ")]")
+	if i := strings.LastIndex(field, " "); i > split {
+		name = strings.TrimSpace(field[i+1:])
+		field = strings.TrimSpace(field[:i])
+	}
+	if !isTupleType(field) {
+		return eABI.ArgumentMarshaling{Name: name, Type: field}, nil
+	}
+	components, suffix, err := parseTuple(field)
+	if err != nil {
+		return eABI.ArgumentMarshaling{}, err
+	}
+	return eABI.ArgumentMarshaling{Name: name, Type: "tuple" + suffix, Components: components}, nil
+}
+
+// splitTopLevel split list by commas outside parenthesis
+func splitTopLevel(list string) []string {
+	if len(strings.TrimSpace(list)) == 0 {
+		return nil
+	}
+	fields := make([]string, 0)
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				fields = append(fields, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(fields, list[start:])
+}
@@ -0,0 +1,182 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+)
+
+var addressType = reflect.TypeOf(eCommon.Address{})
+
+// NewArguments convert ABI entry params into parser arguments
+func NewArguments(params []*core.SmartContract_ABI_Entry_Param) (eABI.Arguments, error) {
+	arguments := eABI.Arguments{}
+	for _, p := range params {
+		ty, err := NewType(p.Type)
+		if err != nil {
+			return nil, fmt.Errorf("invalid param %s: %+v", p.Type, err)
+		}
+		arguments = append(arguments, eABI.Argument{
+			Name:    p.Name,
+			Type:    ty,
+			Indexed: p.Indexed,
+		})
+	}
+	return arguments, nil
+}
+
+// EntrySignature returns the canonical signature of an ABI entry, e.g. transfer(address,uint256)
+func EntrySignature(entry *core.SmartContract_ABI_Entry) (string, error) {
+	arguments, err := NewArguments(entry.Inputs)
+	if err != nil {
+		return "", err
+	}
+	types := make([]string, len(arguments))
+	for i, arg := range arguments {
+		types[i] = arg.Type.String()
+	}
+	return fmt.Sprintf("%s(%s)", entry.Name, strings.Join(types, ",")), nil
+}
+
+// GetMethodEntry find a function entry by name or by full signature (name(type,...))
+func GetMethodEntry(ABI *core.SmartContract_ABI, method string) (*core.SmartContract_ABI_Entry, error) {
+	if ABI == nil {
+		return nil, fmt.Errorf("invalid contract abi")
+	}
+	byName := !strings.Contains(method, "(")
+	for _, entry := range ABI.Entrys {
+		if entry.Type != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		if byName {
+			if entry.Name == method {
+				return entry, nil
+			}
+			continue
+		}
+		signature, err := EntrySignature(entry)
+		if err != nil {
+			return nil, err
+		}
+		if signature == strings.ReplaceAll(method, " ", "") {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("method %s not found", method)
+}
+
+// Unpack method outputs from data, addresses are returned in base58
+func Unpack(ABI *core.SmartContract_ABI, method string, data []byte) ([]interface{}, error) {
+	entry, err := GetMethodEntry(ABI, method)
+	if err != nil {
+		return nil, err
+	}
+	arguments, err := NewArguments(entry.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	values, err := arguments.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertAddresses(values), nil
+}
+
+// DecodeInput match data selector against ABI functions and decode the call arguments,
+// addresses are returned in base58
+func DecodeInput(ABI *core.SmartContract_ABI, data []byte) (string, []interface{}, error) {
+	if ABI == nil {
+		return "", nil, fmt.Errorf("invalid contract abi")
+	}
+	if len(data) < 4 {
+		return "", nil, fmt.Errorf("invalid call data length: %d", len(data))
+	}
+	for _, entry := range ABI.Entrys {
+		if entry.Type != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		signature, err := EntrySignature(entry)
+		if err != nil {
+			return "", nil, err
+		}
+		if !bytes.Equal(Signature(signature), data[:4]) {
+			continue
+		}
+		arguments, err := NewArguments(entry.Inputs)
+		if err != nil {
+			return "", nil, err
+		}
+		values, err := arguments.Unpack(data[4:])
+		if err != nil {
+			return "", nil, fmt.Errorf("unpack %s: %v", signature, err)
+		}
+		return entry.Name, ConvertAddresses(values), nil
+	}
+	return "", nil, fmt.Errorf("method selector %x not found", data[:4])
+}
+
+// ConvertAddresses replace every ethereum address in values by its TRON base58 form,
+// tuples are returned as map[string]interface{} keyed by component name
+func ConvertAddresses(values []interface{}) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = convertValue(reflect.ValueOf(v))
+	}
+	return result
+}
+
+func convertValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == addressType {
+		return toBase58(v.Interface().(eCommon.Address))
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !needsConversion(v.Type().Elem()) {
+			return v.Interface()
+		}
+		list := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list[i] = convertValue(v.Index(i))
+		}
+		return list
+	case reflect.Struct:
+		tuple := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			name := v.Type().Field(i).Tag.Get("json")
+			if len(name) == 0 {
+				name = v.Type().Field(i).Name
+			}
+			tuple[name] = convertValue(v.Field(i))
+		}
+		return tuple
+	}
+	return v.Interface()
+}
+
+func needsConversion(t reflect.Type) bool {
+	switch {
+	case t == addressType:
+		return true
+	case t.Kind() == reflect.Struct:
+		return true
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return needsConversion(t.Elem())
+	}
+	return false
+}
+
+func toBase58(addr eCommon.Address) string {
+	tronAddr := make([]byte, 0, tron.AddressLength)
+	tronAddr = append(tronAddr, tron.TronBytePrefix)
+	tronAddr = append(tronAddr, addr.Bytes()...)
+	return tron.Address(tronAddr).String()
+}
@@ -0,0 +1,91 @@
+package account_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/account"
+	"github.com/EntySquare/chain-util/pkg/tron/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	store.SetBackend(store.NewMemoryBackend())
+	defer store.SetBackend(nil)
+
+	info, err := store.NewAccountInName("alice", "secret")
+	require.Nil(t, err)
+
+	var out bytes.Buffer
+	require.Nil(t, account.ExportPrivateKeyTo(&out, info.Address, "secret"))
+	address, err := tron.AddressFromPrivateKey(strings.TrimSpace(out.String()))
+	require.Nil(t, err)
+	assert.Equal(t, info.Address, address)
+
+	dir := t.TempDir()
+	keyFile, err := account.ExportKeystore(info.Address, filepath.Join(dir, "keys"), "secret")
+	require.Nil(t, err)
+	stat, err := os.Stat(keyFile)
+	require.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+
+	_, err = account.ExportKeyJSON(info.Address, "wrong")
+	assert.NotNil(t, err)
+	_, err = account.ExportKeyJSON("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1", "secret")
+	assert.NotNil(t, err)
+
+	// the key is already in the keystore
+	keyJSON, err := os.ReadFile(keyFile)
+	require.Nil(t, err)
+	_, err = account.ImportKeyStoreFrom(bytes.NewReader(keyJSON), "bob", "secret")
+	assert.NotNil(t, err)
+
+	require.Nil(t, store.RemoveAccountName("alice"))
+	report := account.ImportKeyStores([]string{keyFile, filepath.Join(dir, "missing.key"), keyFile}, "secret")
+	require.Len(t, report.Imported, 1)
+	assert.Equal(t, info.Address, report.Imported[0].Address)
+	require.Len(t, report.Failed, 2)
+	assert.Equal(t, keyFile, report.Failed[1].Path)
+	assert.NotNil(t, report.Failed[1].Err)
+
+	addresses, err := store.AddressesFromAccountName(report.Imported[0].Name)
+	require.Nil(t, err)
+	assert.Equal(t, []string{info.Address}, addresses)
+
+	keys, err := account.NamedPrivateKeys(report.Imported[0].Name, "secret")
+	require.Nil(t, err)
+	require.Len(t, keys, 1)
+	address, err = tron.AddressFromPrivateKey(keys[0].RevealHex())
+	require.Nil(t, err)
+	assert.Equal(t, info.Address, address)
+	keys[0].Destroy()
+	_, err = account.NamedPrivateKeys("nobody", "secret")
+	assert.NotNil(t, err)
+
+	// the file name does not matter, hidden and backup names included
+	require.Nil(t, store.RemoveAccountName(report.Imported[0].Name))
+	hidden := filepath.Join(dir, ".alice.json~")
+	require.Nil(t, os.WriteFile(hidden, keyJSON, 0600))
+	_, err = account.ImportKeyStore(hidden, "carol", "secret")
+	require.Nil(t, err)
+	addresses, err = store.AddressesFromAccountName("carol")
+	require.Nil(t, err)
+	assert.Equal(t, []string{info.Address}, addresses)
+}
+
+func TestCreateNewLocalAccount(t *testing.T) {
+	store.SetBackend(store.NewMemoryBackend())
+	defer store.SetBackend(nil)
+
+	mnemonic := "bag car educate river behind lumber fee seminar spend air stuff phrase mango basket fine crystal number strong eight what spawn impact crater surprise"
+	hardened := uint32(1 << 31)
+	assert.NotNil(t, account.CreateNewLocalAccount(&account.Creation{Name: "bad", Passphrase: "secret", Mnemonic: "not a mnemonic"}))
+	assert.NotNil(t, account.CreateNewLocalAccount(&account.Creation{Name: "bad", Passphrase: "secret", Mnemonic: mnemonic, HdIndexNumber: &hardened}))
+	assert.NotNil(t, account.CreateNewLocalAccount(&account.Creation{Name: "bad", Passphrase: "secret", Mnemonic: mnemonic, HdAccountNumber: &hardened}))
+	assert.Nil(t, account.CreateNewLocalAccount(&account.Creation{Name: "good", Passphrase: "secret", Mnemonic: mnemonic}))
+}
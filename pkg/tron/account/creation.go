@@ -20,11 +20,25 @@ type Creation struct {
 func CreateNewLocalAccount(candidate *Creation) error {
 	ks := store.FromAccountName(candidate.Name)
 	if candidate.Mnemonic == "" {
-		candidate.Mnemonic, _ = pkg.GetMnemonicBy256()
+		mnemonic, err := pkg.GetMnemonicBy256()
+		if err != nil {
+			return err
+		}
+		candidate.Mnemonic = mnemonic
 	}
-	// Hardcoded index of 0 for brandnew account.
-	private, _ := keys.FromMnemonicSeedAndPassphrase(candidate.Mnemonic, candidate.MnemonicPassphrase, 0)
-	_, err := ks.ImportECDSA(private.ToECDSA(), candidate.Passphrase)
+	// m/44'/195'/account'/0/index, both default to 0
+	account, index := uint32(0), uint32(0)
+	if candidate.HdAccountNumber != nil {
+		account = *candidate.HdAccountNumber
+	}
+	if candidate.HdIndexNumber != nil {
+		index = *candidate.HdIndexNumber
+	}
+	private, err := keys.FromMnemonicAccountIndex(candidate.Mnemonic, candidate.MnemonicPassphrase, account, index)
+	if err != nil {
+		return err
+	}
+	_, err = ks.ImportECDSA(private.ToECDSA(), candidate.Passphrase)
 	if err != nil {
 		return err
 	}
@@ -2,43 +2,112 @@ package account
 
 import (
 	"fmt"
+	"github.com/EntySquare/chain-util/pkg/secret"
+	"github.com/EntySquare/chain-util/pkg/tron"
 	"github.com/EntySquare/chain-util/pkg/tron/keystore"
 	"github.com/EntySquare/chain-util/pkg/tron/store"
+	"io"
+	"os"
 	"path/filepath"
 )
 
 // ExportPrivateKey from account
 func ExportPrivateKey(address, passphrase string) error {
-	ks := store.FromAddress(address)
+	return ExportPrivateKeyTo(os.Stdout, address, passphrase)
+}
+
+// ExportPrivateKeyTo writes the hex private key of address to w
+func ExportPrivateKeyTo(w io.Writer, address, passphrase string) error {
+	key, err := PrivateKey(address, passphrase)
+	if err != nil {
+		return err
+	}
+	defer key.Destroy()
+	_, err = fmt.Fprintf(w, "%s\n", key.RevealHex())
+	return err
+}
+
+// PrivateKey of address, Destroy it once done
+func PrivateKey(address, passphrase string) (secret.PrivateKey, error) {
+	ks, account, err := findAccount(address)
+	if err != nil {
+		return secret.PrivateKey{}, err
+	}
+	_, key, err := ks.GetDecryptedKey(account, passphrase)
+	if err != nil {
+		return secret.PrivateKey{}, err
+	}
+	defer secret.ZeroECDSA(key.PrivateKey)
+	return secret.PrivateKeyFromECDSA(key.PrivateKey), nil
+}
+
+// NamedPrivateKeys returns the keys of every account held by the alias name,
+// Destroy them once done. PrivateKey returns the key of a single address.
+func NamedPrivateKeys(name, passphrase string) ([]secret.PrivateKey, error) {
+	ks := store.FromAccountName(name)
 	allAccounts := ks.Accounts()
+	if len(allAccounts) == 0 {
+		return nil, fmt.Errorf("account %s not found in keystore", name)
+	}
+	keys := make([]secret.PrivateKey, 0, len(allAccounts))
 	for _, account := range allAccounts {
 		_, key, err := ks.GetDecryptedKey(keystore.Account{Address: account.Address}, passphrase)
 		if err != nil {
-			return err
+			for _, k := range keys {
+				k.Destroy()
+			}
+			return nil, err
 		}
-		fmt.Printf("%064x\n", key.PrivateKey.D)
+		keys = append(keys, secret.PrivateKeyFromECDSA(key.PrivateKey))
+		secret.ZeroECDSA(key.PrivateKey)
+	}
+	return keys, nil
+}
+
+// ExportKeyJSON returns the keystore JSON of address, encrypted with passphrase
+func ExportKeyJSON(address, passphrase string) ([]byte, error) {
+	ks, account, err := findAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	return ks.Export(account, passphrase, passphrase)
+}
+
+// ExportKeystoreTo writes the keystore JSON of address to w
+func ExportKeystoreTo(w io.Writer, address, passphrase string) error {
+	keyJSON, err := ExportKeyJSON(address, passphrase)
+	if err != nil {
+		return err
 	}
-	return nil
+	_, err = w.Write(keyJSON)
+	return err
 }
 
 // ExportKeystore to file
 func ExportKeystore(address, path, passphrase string) (string, error) {
-	ks := store.FromAddress(address)
-	allAccounts := ks.Accounts()
 	dirPath, err := filepath.Abs(path)
 	if err != nil {
 		return "", err
 	}
 	outFile := filepath.Join(dirPath, fmt.Sprintf("%s.key", address))
-	for _, account := range allAccounts {
-		keyFile, err := ks.Export(keystore.Account{Address: account.Address}, passphrase, passphrase)
-		if err != nil {
-			return "", err
-		}
-		e := writeToFile(outFile, string(keyFile))
-		if e != nil {
-			return "", e
-		}
+	keyJSON, err := ExportKeyJSON(address, passphrase)
+	if err != nil {
+		return "", err
+	}
+	if err := writeToFile(outFile, keyJSON); err != nil {
+		return "", err
 	}
 	return outFile, nil
 }
+
+func findAccount(address string) (*keystore.KeyStore, keystore.Account, error) {
+	addr, err := tron.Base58ToAddress(address)
+	if err != nil {
+		return nil, keystore.Account{}, fmt.Errorf("address not valid: %s", address)
+	}
+	ks := store.FromAddress(address)
+	if ks == nil {
+		return nil, keystore.Account{}, fmt.Errorf("address %s not found in keystore", address)
+	}
+	return ks, keystore.Account{Address: addr}, nil
+}
@@ -3,11 +3,11 @@ package account
 import (
 	"encoding/hex"
 	"fmt"
+	"github.com/EntySquare/chain-util/pkg/secret"
 	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
 	"github.com/EntySquare/chain-util/pkg/tron/mnemonic"
 	"github.com/EntySquare/chain-util/pkg/tron/store"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
-	"github.com/mitchellh/go-homedir"
 	"io"
 	"io/ioutil"
 	"os"
@@ -18,17 +18,27 @@ import (
 	mapset "github.com/deckarep/golang-set"
 )
 
+// ImportResult is the outcome of importing one keystore file
+type ImportResult struct {
+	Path    string
+	Name    string
+	Address string
+	Err     error
+}
+
+// ImportReport is the result of ImportKeyStores
+type ImportReport struct {
+	Imported []ImportResult
+	Failed   []ImportResult
+}
+
 // ImportFromPrivateKey allows import of an ECDSA private key
 func ImportFromPrivateKey(privateKey, name, passphrase string) (string, error) {
 	privateKey = strings.TrimPrefix(privateKey, "0x")
 
-	if name == "" {
-		name = generateName() + "-imported"
-		for store.DoesNamedAccountExist(name) {
-			name = generateName() + "-imported"
-		}
-	} else if store.DoesNamedAccountExist(name) {
-		return "", fmt.Errorf("account %s already exists", name)
+	name, err := accountName(name)
+	if err != nil {
+		return "", err
 	}
 
 	privateKeyBytes, err := hex.DecodeString(privateKey)
@@ -46,12 +56,29 @@ func ImportFromPrivateKey(privateKey, name, passphrase string) (string, error) {
 	return name, err
 }
 
-func generateName() string {
-	words := strings.Split(mnemonic.Generate(), " ")
+// accountName checks name is free, or generates one when empty
+func accountName(name string) (string, error) {
+	accounts, err := store.LocalAccounts()
+	if err != nil {
+		return "", err
+	}
 	existingAccounts := mapset.NewSet()
-	for a := range store.LocalAccounts() {
+	for _, a := range accounts {
 		existingAccounts.Add(a)
 	}
+	if name == "" {
+		name = generateName(existingAccounts) + "-imported"
+		for existingAccounts.Contains(name) {
+			name = generateName(existingAccounts) + "-imported"
+		}
+	} else if existingAccounts.Contains(name) {
+		return "", fmt.Errorf("account %s already exists", name)
+	}
+	return name, nil
+}
+
+func generateName(existingAccounts mapset.Set) string {
+	words := strings.Split(mnemonic.Generate(), " ")
 	foundName := false
 	acct := ""
 	i := 0
@@ -72,60 +99,110 @@ func generateName() string {
 	return acct
 }
 
-func writeToFile(path string, data string) error {
-	currDir, _ := os.Getwd()
+// writeToFile atomically replaces path with data, readable by the owner only
+func writeToFile(path string, data []byte) error {
 	path, err := filepath.Abs(path)
 	if err != nil {
 		return err
 	}
-	os.MkdirAll(filepath.Dir(path), 0777)
-	os.Chdir(filepath.Dir(path))
-	file, err := os.Create(filepath.Base(path))
-	if err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return err
 	}
-	defer file.Close()
-
-	_, err = io.WriteString(file, data)
+	// TempFile creates the file with mode 0600
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
 	if err != nil {
 		return err
 	}
-	os.Chdir(currDir)
-	return file.Sync()
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return nil
 }
 
 // ImportKeyStore imports a keystore along with a password
 func ImportKeyStore(keyPath, name, passphrase string) (string, error) {
-	keyPath, err := filepath.Abs(keyPath)
+	name, _, err := importKeyStoreFile(keyPath, name, passphrase)
+	return name, err
+}
+
+// ImportKeyStoreFrom imports the keystore JSON read from r
+func ImportKeyStoreFrom(r io.Reader, name, passphrase string) (string, error) {
+	keyJSON, err := ioutil.ReadAll(r)
 	if err != nil {
 		return "", err
 	}
-	keyJSON, readError := ioutil.ReadFile(keyPath)
-	if readError != nil {
-		return "", readError
-	}
-	if name == "" {
-		name = generateName() + "-imported"
-		for store.DoesNamedAccountExist(name) {
-			name = generateName() + "-imported"
+	return ImportKeyJSON(keyJSON, name, passphrase)
+}
+
+// ImportKeyJSON imports a keystore JSON along with a password
+func ImportKeyJSON(keyJSON []byte, name, passphrase string) (string, error) {
+	name, _, err := importKeyJSON(keyJSON, name, passphrase)
+	return name, err
+}
+
+// ImportKeyStores imports every keystore file under a generated name,
+// a failing file does not stop the others
+func ImportKeyStores(keyPaths []string, passphrase string) *ImportReport {
+	report := new(ImportReport)
+	for _, keyPath := range keyPaths {
+		name, address, err := importKeyStoreFile(keyPath, "", passphrase)
+		result := ImportResult{Path: keyPath, Name: name, Address: address, Err: err}
+		if err != nil {
+			report.Failed = append(report.Failed, result)
+		} else {
+			report.Imported = append(report.Imported, result)
 		}
-	} else if store.DoesNamedAccountExist(name) {
-		return "", fmt.Errorf("account %s already exists", name)
 	}
-	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	return report
+}
+
+func importKeyStoreFile(keyPath, name, passphrase string) (string, string, error) {
+	keyPath, err := filepath.Abs(keyPath)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
+	keyJSON, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return "", "", err
+	}
+	return importKeyJSON(keyJSON, name, passphrase)
+}
 
-	hasAddress := store.FromAddress(key.Address.String()) != nil
-	if hasAddress {
-		return "", fmt.Errorf("address %s already exists in keystore", key.Address.String())
+// importKeyJSON stores keyJSON as is under the keystore name of the key,
+// whatever the name of the imported file, and returns the account name and address
+func importKeyJSON(keyJSON []byte, name, passphrase string) (string, string, error) {
+	name, err := accountName(name)
+	if err != nil {
+		return "", "", err
 	}
-	uDir, _ := homedir.Dir()
-	newPath := filepath.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName, name, filepath.Base(keyPath))
-	err = writeToFile(newPath, string(keyJSON))
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return name, nil
+	secret.ZeroECDSA(key.PrivateKey)
+
+	address := key.Address.String()
+	if store.FromAddress(address) != nil {
+		return "", "", fmt.Errorf("address %s already exists in keystore", address)
+	}
+	storage := store.CurrentBackend().Storage(name)
+	if err := storage.Write(storage.Join(keystore.KeyFileName(key.Address)), keyJSON); err != nil {
+		return "", "", err
+	}
+	return name, address, nil
 }
@@ -2,11 +2,7 @@ package account
 
 import (
 	"fmt"
-	"github.com/EntySquare/chain-util/pkg/tron/common"
 	"github.com/EntySquare/chain-util/pkg/tron/store"
-	"github.com/mitchellh/go-homedir"
-	"os"
-	"path"
 )
 
 // RemoveAccount - removes an account from the keystore
@@ -17,10 +13,5 @@ func RemoveAccount(name string) error {
 		return fmt.Errorf("account %s doesn't exist", name)
 	}
 
-	uDir, _ := homedir.Dir()
-	tronCTLDir := path.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName)
-	accountDir := fmt.Sprintf("%s/%s", tronCTLDir, name)
-	os.RemoveAll(accountDir)
-
-	return nil
+	return store.RemoveAccountName(name)
 }
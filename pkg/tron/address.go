@@ -7,10 +7,10 @@ import (
 	"encoding/base64"
 	"fmt"
 	"github.com/EntySquare/chain-util/pkg"
+	"github.com/EntySquare/chain-util/pkg/secret"
 
 	"github.com/EntySquare/chain-util/pkg/tron/common"
 	ethereumCommon "github.com/ethereum/go-ethereum/common"
-	"github.com/tyler-smith/go-bip39"
 	"math/big"
 	"regexp"
 
@@ -128,6 +128,29 @@ func AddressFromPrivateKey(privateKeyHex string) (string, error) {
 	return tronAddress.String(), nil
 }
 
+// AddressFromPrivateKeySecret 从私钥生成地址
+func AddressFromPrivateKeySecret(privateKey secret.PrivateKey) (string, error) {
+	publicKey, err := pkg.AddressFromPrivateKeySecret(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return PublicKeyToAddress(*publicKey).String(), nil
+}
+
+// CreateWalletSecret Tron 创建钱包 生成地址和私钥 助记词，私钥和助记词不会被打印或序列化
+func CreateWalletSecret() (string, secret.PrivateKey, secret.Mnemonic, error) {
+	mnemonic, err := pkg.GetMnemonicSecret(256)
+	if err != nil {
+		return "", secret.PrivateKey{}, secret.Mnemonic{}, err
+	}
+	address, privateKey, err := GenerateAddressFromMnemonicSecret(mnemonic)
+	if err != nil {
+		mnemonic.Destroy()
+		return "", secret.PrivateKey{}, secret.Mnemonic{}, err
+	}
+	return address, privateKey, mnemonic, nil
+}
+
 // CreateWallet Tron 创建钱包 生成地址和私钥 助记词
 func CreateWallet() (address string, privateKey string, mnemonic string, err error) {
 
@@ -152,27 +175,42 @@ func IsValidAddress(address string) bool {
 
 // GenerateAddressFromMnemonic Tron 根据助记词生成地址和私钥
 func GenerateAddressFromMnemonic(mnemonic string) (address string, privateKey string, err error) {
+	m := secret.NewMnemonic(mnemonic)
+	defer m.Destroy()
+	address, key, err := GenerateAddressFromMnemonicSecret(m)
+	if err != nil {
+		return "", "", err
+	}
+	defer key.Destroy()
+	return address, key.RevealHex(), nil
+}
+
+// GenerateAddressFromMnemonicSecret Tron 根据助记词生成地址和私钥，私钥不会被打印或序列化
+func GenerateAddressFromMnemonicSecret(mnemonic secret.Mnemonic) (string, secret.PrivateKey, error) {
 	// 生成私钥  这里可以选择传入指定密码或者空字符串，不同密码生成的助记词不同
-	seed := bip39.NewSeed(mnemonic, "")
+	seed, err := mnemonic.Seed("")
+	if err != nil {
+		return "", secret.PrivateKey{}, err
+	}
+	defer secret.Zero(seed)
 	// 使用种子生成 BIP32 主密钥
 	wallet, err := pkg.TronNewFromSeed(seed)
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
 	path, err := pkg.MustParseDerivationPath(pkg.TronDerivationPath) //最后一位是同一个助记词的地址id，从0开始，相同助记词可以生产无限个地址
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
 	account, err := wallet.Derive(path, false)
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
-	privateKey, err = wallet.PrivateKeyHex(account)
+	privateKey, err := wallet.PrivateKeySecret(account)
 	if err != nil {
-		return "", "", err
+		return "", secret.PrivateKey{}, err
 	}
-	tronAddress := AddressToTronAddress(account.Address)
-	return tronAddress.String(), privateKey, nil
+	return AddressToTronAddress(account.Address).String(), privateKey, nil
 }
 
 // AddressToTronAddress returns address from ecdsa public key
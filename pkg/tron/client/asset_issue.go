@@ -0,0 +1,317 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/account"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/common/numeric"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// Limits enforced by the node on asset issuance
+const (
+	assetNameMaxLength        = 32
+	assetDescriptionMaxLength = 200
+	assetURLMaxLength         = 256
+	assetMaxPrecision         = 6
+	assetMaxNetLimit          = 57_600_000_000 // one day of network bandwidth
+	assetMaxFrozenSupplies    = 10
+	assetMinFrozenDays        = 1
+	assetMaxFrozenDays        = 3652
+)
+
+// AssetFrozenSupply part of the supply locked for the issuer
+type AssetFrozenSupply struct {
+	Amount int64
+	Days   int64
+}
+
+// AssetIssueSpec TRC10 issuance parameters
+type AssetIssueSpec struct {
+	Name        string
+	Abbr        string
+	Description string
+	URL         string
+	Precision   int32
+	TotalSupply int64
+	// TrxNum SUN exchanged for IcoNum token units during the ICO
+	TrxNum                  int32
+	IcoNum                  int32
+	StartTime               time.Time
+	EndTime                 time.Time
+	FreeAssetNetLimit       int64
+	PublicFreeAssetNetLimit int64
+	VoteScore               int32
+	FrozenSupply            []AssetFrozenSupply
+}
+
+// validAssetName printable ASCII without spaces, as checked by the node
+func validAssetName(name string) bool {
+	if len(name) == 0 || len(name) > assetNameMaxLength {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] < 0x21 || name[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate check the spec against the node rules, start time must be in the future
+func (s *AssetIssueSpec) Validate() error {
+	if !validAssetName(s.Name) {
+		return fmt.Errorf("invalid asset name %q: 1 to %d printable ASCII characters without spaces", s.Name, assetNameMaxLength)
+	}
+	if strings.EqualFold(s.Name, "trx") {
+		return fmt.Errorf("invalid asset name %q: reserved", s.Name)
+	}
+	// the abbr is optional, the node only checks it when set
+	if s.Abbr != "" && !validAssetName(s.Abbr) {
+		return fmt.Errorf("invalid asset abbr %q: up to %d printable ASCII characters without spaces", s.Abbr, assetNameMaxLength)
+	}
+	if len(s.Description) > assetDescriptionMaxLength {
+		return fmt.Errorf("invalid asset description: more than %d bytes", assetDescriptionMaxLength)
+	}
+	if len(s.URL) == 0 || len(s.URL) > assetURLMaxLength {
+		return fmt.Errorf("invalid asset url: 1 to %d bytes", assetURLMaxLength)
+	}
+	if s.Precision < 0 || s.Precision > assetMaxPrecision {
+		return fmt.Errorf("invalid asset precision %d: 0 to %d", s.Precision, assetMaxPrecision)
+	}
+	if s.TotalSupply <= 0 {
+		return fmt.Errorf("invalid asset total supply %d", s.TotalSupply)
+	}
+	if s.TrxNum <= 0 || s.IcoNum <= 0 {
+		return fmt.Errorf("invalid asset exchange rate %d/%d", s.TrxNum, s.IcoNum)
+	}
+	if !s.StartTime.After(time.Now()) {
+		return fmt.Errorf("invalid asset start time %v: not in the future", s.StartTime)
+	}
+	if !s.EndTime.After(s.StartTime) {
+		return fmt.Errorf("invalid asset end time %v: not after start time", s.EndTime)
+	}
+	if s.FreeAssetNetLimit < 0 || s.FreeAssetNetLimit >= assetMaxNetLimit {
+		return fmt.Errorf("invalid free asset net limit %d", s.FreeAssetNetLimit)
+	}
+	if s.PublicFreeAssetNetLimit < 0 || s.PublicFreeAssetNetLimit >= assetMaxNetLimit {
+		return fmt.Errorf("invalid public free asset net limit %d", s.PublicFreeAssetNetLimit)
+	}
+	if len(s.FrozenSupply) > assetMaxFrozenSupplies {
+		return fmt.Errorf("invalid frozen supply: more than %d entries", assetMaxFrozenSupplies)
+	}
+	remaining := s.TotalSupply
+	for _, frozen := range s.FrozenSupply {
+		if frozen.Amount <= 0 {
+			return fmt.Errorf("invalid frozen supply amount %d", frozen.Amount)
+		}
+		if frozen.Days < assetMinFrozenDays || frozen.Days > assetMaxFrozenDays {
+			return fmt.Errorf("invalid frozen supply days %d: %d to %d", frozen.Days, assetMinFrozenDays, assetMaxFrozenDays)
+		}
+		remaining -= frozen.Amount
+		if remaining < 0 {
+			return fmt.Errorf("invalid frozen supply: more than total supply")
+		}
+	}
+	return nil
+}
+
+// Contract returns the validated issue contract of owner
+func (s *AssetIssueSpec) Contract(owner string) (*core.AssetIssueContract, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	contract := &core.AssetIssueContract{
+		Name:                    []byte(s.Name),
+		Abbr:                    []byte(s.Abbr),
+		Description:             []byte(s.Description),
+		Url:                     []byte(s.URL),
+		Precision:               s.Precision,
+		TotalSupply:             s.TotalSupply,
+		TrxNum:                  s.TrxNum,
+		Num:                     s.IcoNum,
+		StartTime:               s.StartTime.UnixNano() / int64(time.Millisecond),
+		EndTime:                 s.EndTime.UnixNano() / int64(time.Millisecond),
+		FreeAssetNetLimit:       s.FreeAssetNetLimit,
+		PublicFreeAssetNetLimit: s.PublicFreeAssetNetLimit,
+		VoteScore:               s.VoteScore,
+	}
+	var err error
+	if contract.OwnerAddress, err = common.DecodeCheck(owner); err != nil {
+		return nil, err
+	}
+	for _, frozen := range s.FrozenSupply {
+		contract.FrozenSupply = append(contract.FrozenSupply, &core.AssetIssueContract_FrozenSupply{
+			FrozenAmount: frozen.Amount,
+			FrozenDays:   frozen.Days,
+		})
+	}
+	return contract, nil
+}
+
+// AssetIssueWithSpec create a new asset TRC10 from a validated spec
+func (g *GrpcClient) AssetIssueWithSpec(from string, spec *AssetIssueSpec) (*api.TransactionExtention, error) {
+	contract, err := spec.Contract(from)
+	if err != nil {
+		return nil, fmt.Errorf("create asset issue error: %v", err)
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	tx, err := g.Client.CreateAssetIssue2(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	return tx, nil
+}
+
+// AssetIterator pages through every TRC10 asset
+type AssetIterator struct {
+	client   *GrpcClient
+	offset   int64
+	pageSize int64
+	done     bool
+}
+
+// AssetIssues returns an iterator over the TRC10 assets, starting at offset
+func (g *GrpcClient) AssetIssues(offset, pageSize int64) *AssetIterator {
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return &AssetIterator{client: g, offset: offset, pageSize: pageSize}
+}
+
+// Offset of the next page
+func (it *AssetIterator) Offset() int64 {
+	return it.offset
+}
+
+// Done returns true once the last page was read
+func (it *AssetIterator) Done() bool {
+	return it.done
+}
+
+// Next returns the next page of assets, nil when done
+func (it *AssetIterator) Next() ([]*core.AssetIssueContract, error) {
+	if it.done {
+		return nil, nil
+	}
+
+	ctx, cancel := it.client.getContext()
+	defer cancel()
+
+	list, err := it.client.Client.GetPaginatedAssetIssueList(ctx, GetPaginatedMessage(it.offset, it.pageSize))
+	if err != nil {
+		return nil, err
+	}
+	assets := list.GetAssetIssue()
+	it.offset += int64(len(assets))
+	it.done = int64(len(assets)) < it.pageSize
+	for _, asset := range assets {
+		cacheTRC10Metadata(it.client, asset)
+	}
+	return assets, nil
+}
+
+// TRC10Metadata immutable asset properties
+type TRC10Metadata struct {
+	ID        string
+	Name      string
+	Abbr      string
+	Precision int32
+	Owner     string
+}
+
+// trc10Metadata cache keyed by node and token id
+var trc10Metadata = struct {
+	sync.RWMutex
+	tokens map[string]*TRC10Metadata
+}{tokens: make(map[string]*TRC10Metadata)}
+
+// cacheTRC10Metadata store the metadata of asset
+func cacheTRC10Metadata(g *GrpcClient, asset *core.AssetIssueContract) *TRC10Metadata {
+	metadata := &TRC10Metadata{
+		ID:        asset.GetId(),
+		Name:      string(asset.GetName()),
+		Abbr:      string(asset.GetAbbr()),
+		Precision: asset.GetPrecision(),
+		Owner:     tron.Address(asset.GetOwnerAddress()).String(),
+	}
+	if metadata.ID == "" {
+		return metadata
+	}
+	trc10Metadata.Lock()
+	trc10Metadata.tokens[g.Address+"/"+metadata.ID] = metadata
+	trc10Metadata.Unlock()
+	return metadata
+}
+
+// GetTRC10Metadata returns name, abbr and precision of a token, loaded once per id
+func (g *GrpcClient) GetTRC10Metadata(tokenID string) (*TRC10Metadata, error) {
+	trc10Metadata.RLock()
+	metadata, ok := trc10Metadata.tokens[g.Address+"/"+tokenID]
+	trc10Metadata.RUnlock()
+	if ok {
+		return metadata, nil
+	}
+
+	asset, err := g.GetAssetIssueByID(tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("token %s: %v", tokenID, err)
+	}
+	if asset.GetId() != tokenID {
+		return nil, fmt.Errorf("token %s not found", tokenID)
+	}
+	return cacheTRC10Metadata(g, asset), nil
+}
+
+// AccountAsset TRC10 balance with its metadata
+type AccountAsset struct {
+	TRC10Metadata
+	Balance int64
+	Amount  numeric.Dec
+}
+
+// TRC10ToAmount convert raw asset units into a decimal amount
+func TRC10ToAmount(raw int64, precision int32) numeric.Dec {
+	return numeric.NewDecWithPrec(raw, int64(precision))
+}
+
+// GetAccountAssets returns the TRC10 balances of an account with names and
+// precisions, ordered by token id
+func (g *GrpcClient) GetAccountAssets(acc *account.Account) ([]*AccountAsset, error) {
+	assets := make([]*AccountAsset, 0, len(acc.Assets))
+	for tokenID, balance := range acc.Assets {
+		metadata, err := g.GetTRC10Metadata(tokenID)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, &AccountAsset{
+			TRC10Metadata: *metadata,
+			Balance:       balance,
+			Amount:        TRC10ToAmount(balance, metadata.Precision),
+		})
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		if len(assets[i].ID) != len(assets[j].ID) {
+			return len(assets[i].ID) < len(assets[j].ID)
+		}
+		return assets[i].ID < assets[j].ID
+	})
+	return assets, nil
+}
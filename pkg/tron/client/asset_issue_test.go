@@ -0,0 +1,102 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestAssetIssueSpec_Validate(t *testing.T) {
+	valid := func() *client.AssetIssueSpec {
+		return &client.AssetIssueSpec{
+			Name:         "EntyToken",
+			Abbr:         "ENTY",
+			URL:          "https://entysquare.com",
+			Precision:    6,
+			TotalSupply:  1000000,
+			TrxNum:       1,
+			IcoNum:       1,
+			StartTime:    time.Now().Add(time.Hour),
+			EndTime:      time.Now().Add(48 * time.Hour),
+			FrozenSupply: []client.AssetFrozenSupply{{Amount: 1000, Days: 30}},
+		}
+	}
+	require.Nil(t, valid().Validate())
+	noAbbr := valid()
+	noAbbr.Abbr = ""
+	assert.Nil(t, noAbbr.Validate())
+
+	tests := map[string]func(*client.AssetIssueSpec){
+		"name with space":     func(s *client.AssetIssueSpec) { s.Name = "Enty Token" },
+		"reserved name":       func(s *client.AssetIssueSpec) { s.Name = "TRX" },
+		"long name":           func(s *client.AssetIssueSpec) { s.Name = "abcdefghijklmnopqrstuvwxyz0123456" },
+		"non ascii abbr":      func(s *client.AssetIssueSpec) { s.Abbr = "ÉN" },
+		"empty url":           func(s *client.AssetIssueSpec) { s.URL = "" },
+		"precision":           func(s *client.AssetIssueSpec) { s.Precision = 7 },
+		"past start":          func(s *client.AssetIssueSpec) { s.StartTime = time.Now().Add(-time.Hour) },
+		"end before start":    func(s *client.AssetIssueSpec) { s.EndTime = s.StartTime },
+		"frozen days":         func(s *client.AssetIssueSpec) { s.FrozenSupply[0].Days = 3653 },
+		"frozen above supply": func(s *client.AssetIssueSpec) { s.FrozenSupply[0].Amount = 1000001 },
+	}
+	for name, mutate := range tests {
+		spec := valid()
+		mutate(spec)
+		assert.NotNil(t, spec.Validate(), name)
+	}
+
+	spec := valid()
+	contract, err := spec.Contract("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("ENTY"), contract.GetAbbr())
+	assert.Equal(t, spec.StartTime.UnixNano()/int64(time.Millisecond), contract.GetStartTime())
+	require.Len(t, contract.GetFrozenSupply(), 1)
+	assert.Equal(t, int64(30), contract.GetFrozenSupply()[0].GetFrozenDays())
+}
+
+// issueNode records the issue contract it is sent
+type issueNode struct {
+	api.WalletClient
+	contract *core.AssetIssueContract
+}
+
+func (n *issueNode) CreateAssetIssue2(_ context.Context, contract *core.AssetIssueContract, _ ...grpc.CallOption) (*api.TransactionExtention, error) {
+	n.contract = contract
+	return &api.TransactionExtention{Txid: []byte{0x01}, Result: &api.Return{}}, nil
+}
+
+func TestAssetIssue(t *testing.T) {
+	node := &issueNode{}
+	g := client.NewGrpcClient("")
+	g.Client = node
+
+	start := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+	end := start + int64(48*time.Hour/time.Millisecond)
+	// callers of the positional API may leave the abbr empty
+	_, err := g.AssetIssue("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf", "EntyToken", "", "", "https://entysquare.com",
+		6, 1000000, start, end, 0, 0, 1, 1, 0, map[string]string{"30": "1000"})
+	require.Nil(t, err)
+	require.NotNil(t, node.contract)
+	assert.Empty(t, node.contract.GetAbbr())
+	assert.Equal(t, []byte("EntyToken"), node.contract.GetName())
+	assert.Equal(t, start, node.contract.GetStartTime())
+	require.Len(t, node.contract.GetFrozenSupply(), 1)
+	assert.Equal(t, int64(1000), node.contract.GetFrozenSupply()[0].GetFrozenAmount())
+
+	node.contract = nil
+	_, err = g.AssetIssue("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf", "EntyToken", "", "EN TY", "https://entysquare.com",
+		6, 1000000, start, end, 0, 0, 1, 1, 0, nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, node.contract)
+}
+
+func TestTRC10ToAmount(t *testing.T) {
+	assert.Equal(t, "1.500000000000000000", client.TRC10ToAmount(1500000, 6).String())
+	assert.Equal(t, "42.000000000000000000", client.TRC10ToAmount(42, 0).String())
+}
@@ -62,60 +62,25 @@ func (g *GrpcClient) GetAssetIssueList(page int64, limit ...int) (*api.AssetIssu
 	return g.Client.GetPaginatedAssetIssueList(ctx, GetPaginatedMessage(page*useLimit, useLimit))
 }
 
-// AssetIssue create a new asset TRC10
+// AssetIssue create a new asset TRC10, see AssetIssueSpec for the rules
 func (g *GrpcClient) AssetIssue(from, name, description, abbr, urlStr string,
 	precision int32, totalSupply, startTime, endTime, FreeAssetNetLimit, PublicFreeAssetNetLimit int64,
 	trxNum, icoNum, voteScore int32, frozenSupply map[string]string) (*api.TransactionExtention, error) {
-	var err error
-
-	contract := &core.AssetIssueContract{}
-	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
-		return nil, err
-	}
-	contract.Name = []byte(name)
-	contract.Abbr = []byte(abbr)
-	if precision < 0 || precision > 6 {
-		return nil, fmt.Errorf("create asset issue error: precision < 0 || precision > 6")
-	}
-	contract.Precision = precision
-	if totalSupply <= 0 {
-		return nil, fmt.Errorf("create asset issue error: total supply <= 0")
-	}
-	contract.TotalSupply = totalSupply
-	if trxNum <= 0 {
-		return nil, fmt.Errorf("create asset issue error: trxNum <= 0")
-	}
-	contract.TrxNum = trxNum
-
-	if icoNum <= 0 {
-		return nil, fmt.Errorf("create asset issue error: num <= 0")
-	}
-	contract.Num = icoNum
-
-	now := time.Now().UnixNano() / 1000000
-	if startTime <= now {
-		return nil, fmt.Errorf("create asset issue error: start time <= current time")
-	}
-	contract.StartTime = startTime
-
-	if endTime <= startTime {
-		return nil, fmt.Errorf("create asset issue error: end time <= start time")
-	}
-	contract.EndTime = endTime
-
-	if FreeAssetNetLimit < 0 {
-		return nil, fmt.Errorf("create asset issue error: free asset net limit < 0")
-	}
-	contract.FreeAssetNetLimit = FreeAssetNetLimit
-
-	if PublicFreeAssetNetLimit < 0 {
-		return nil, fmt.Errorf("create asset issue error: public free asset net limit < 0")
+	spec := &AssetIssueSpec{
+		Name:                    name,
+		Abbr:                    abbr,
+		Description:             description,
+		URL:                     urlStr,
+		Precision:               precision,
+		TotalSupply:             totalSupply,
+		TrxNum:                  trxNum,
+		IcoNum:                  icoNum,
+		StartTime:               time.Unix(0, startTime*int64(time.Millisecond)),
+		EndTime:                 time.Unix(0, endTime*int64(time.Millisecond)),
+		FreeAssetNetLimit:       FreeAssetNetLimit,
+		PublicFreeAssetNetLimit: PublicFreeAssetNetLimit,
+		VoteScore:               voteScore,
 	}
-	contract.PublicFreeAssetNetLimit = PublicFreeAssetNetLimit
-
-	contract.VoteScore = voteScore
-	contract.Description = []byte(description)
-	contract.Url = []byte(urlStr)
 
 	for key, value := range frozenSupply {
 		amount, err := strconv.ParseInt(value, 10, 64)
@@ -126,29 +91,9 @@ func (g *GrpcClient) AssetIssue(from, name, description, abbr, urlStr string,
 		if err != nil {
 			return nil, fmt.Errorf("create asset issue error: convert error: %v", err)
 		}
-		assetIssueContractFrozenSupply := new(core.
-			AssetIssueContract_FrozenSupply)
-		assetIssueContractFrozenSupply.FrozenAmount = amount
-		assetIssueContractFrozenSupply.FrozenDays = days
-		// add supply to contract
-		contract.FrozenSupply = append(contract.
-			FrozenSupply, assetIssueContractFrozenSupply)
+		spec.FrozenSupply = append(spec.FrozenSupply, AssetFrozenSupply{Amount: amount, Days: days})
 	}
-
-	ctx, cancel := g.getContext()
-	defer cancel()
-
-	tx, err := g.Client.CreateAssetIssue2(ctx, contract)
-	if err != nil {
-		return nil, err
-	}
-	if proto.Size(tx) == 0 {
-		return nil, fmt.Errorf("bad transaction")
-	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
-	}
-	return tx, nil
+	return g.AssetIssueWithSpec(from, spec)
 }
 
 // UpdateAssetIssue information
@@ -0,0 +1,272 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"google.golang.org/grpc"
+)
+
+// Balance operations reported by the balance trace classification
+const (
+	BalanceTransfer = "transfer" // top level TRX transfer or call value
+	BalanceFee      = "fee"      // bandwidth, energy and creation fees
+	BalanceInternal = "internal" // TRX moved by contract internal transactions
+	BalanceReward   = "reward"   // withdrawn voting and block rewards
+	BalanceOther    = "other"    // freeze, unfreeze, exchange and other system contracts
+)
+
+// BalanceChange single TRX balance change of an address
+type BalanceChange struct {
+	Block        int64
+	TxID         string
+	ContractType string
+	Status       string
+	Address      string // base58
+	Amount       int64  // SUN, negative when the balance decreases
+	Operation    string
+}
+
+// getBlockIdentifier returns number and hash of a block
+func (g *GrpcClient) getBlockIdentifier(num int64) (*core.BlockBalanceTrace_BlockIdentifier, error) {
+	block, err := g.GetBlockByNum(num)
+	if err != nil {
+		return nil, err
+	}
+	if len(block.GetBlockid()) == 0 {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+	return &core.BlockBalanceTrace_BlockIdentifier{
+		Hash:   block.GetBlockid(),
+		Number: num,
+	}, nil
+}
+
+// GetBlockBalanceTrace returns every balance change of a block, the node must
+// run with historyBalanceLookup enabled
+func (g *GrpcClient) GetBlockBalanceTrace(num int64) (*core.BlockBalanceTrace, error) {
+	identifier, err := g.getBlockIdentifier(num)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	maxSizeOption := grpc.MaxCallRecvMsgSize(32 * 10e6)
+	result, err := g.Client.GetBlockBalanceTrace(ctx, identifier, maxSizeOption)
+	if err != nil {
+		return nil, fmt.Errorf("Get block balance trace: %v", err)
+	}
+	return result, nil
+}
+
+// GetAccountBalanceAt returns the TRX balance of an address at the end of a block
+func (g *GrpcClient) GetAccountBalanceAt(addr string, num int64) (int64, error) {
+	account, err := common.DecodeCheck(addr)
+	if err != nil {
+		return 0, err
+	}
+	identifier, err := g.getBlockIdentifier(num)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	result, err := g.Client.GetAccountBalance(ctx, &core.AccountBalanceRequest{
+		AccountIdentifier: &core.AccountIdentifier{Address: account},
+		BlockIdentifier:   identifier,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.GetBalance(), nil
+}
+
+// GetBlockBalanceChanges returns the classified balance changes of a block
+func (g *GrpcClient) GetBlockBalanceChanges(num int64) ([]*BalanceChange, error) {
+	trace, err := g.GetBlockBalanceTrace(num)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := g.GetBlockInfoByNum(num)
+	if err != nil {
+		return nil, err
+	}
+	return ClassifyBalanceTrace(trace, infos), nil
+}
+
+// ClassifyBalanceTrace split the block trace per address and operation, using
+// the block receipts to recognize fees: the fee is the last debit of the
+// receipt fee amount in a transaction
+func ClassifyBalanceTrace(trace *core.BlockBalanceTrace, infos *api.TransactionInfoList) []*BalanceChange {
+	fees := make(map[string]int64)
+	for _, info := range infos.GetTransactionInfo() {
+		fees[hex.EncodeToString(info.GetId())] = info.GetFee()
+	}
+
+	number := trace.GetBlockIdentifier().GetNumber()
+	changes := make([]*BalanceChange, 0)
+	for _, tx := range trace.GetTransactionBalanceTrace() {
+		txID := hex.EncodeToString(tx.GetTransactionIdentifier())
+		operations := tx.GetOperation()
+
+		// fee debit (and the matching black hole credit when fees are not burnt)
+		isFee := make(map[int]bool)
+		if fee := fees[txID]; fee > 0 {
+			for i := len(operations) - 1; i >= 0; i-- {
+				if operations[i].GetAmount() == -fee {
+					isFee[i] = true
+					break
+				}
+			}
+			for i := len(operations) - 1; i >= 0; i-- {
+				if operations[i].GetAmount() == fee {
+					isFee[i] = true
+					break
+				}
+			}
+		}
+
+		// call value or transfer amount: first debit and the following credit
+		isTransfer := make(map[int]bool)
+		switch tx.GetType() {
+		case core.Transaction_Contract_TransferContract.String(),
+			core.Transaction_Contract_TriggerSmartContract.String(),
+			core.Transaction_Contract_CreateSmartContract.String():
+			for i := 0; i+1 < len(operations); i++ {
+				if isFee[i] || isFee[i+1] {
+					continue
+				}
+				if operations[i].GetAmount() < 0 && operations[i].GetAmount() == -operations[i+1].GetAmount() {
+					isTransfer[i] = true
+					isTransfer[i+1] = true
+				}
+				break
+			}
+		}
+
+		for i, op := range operations {
+			change := &BalanceChange{
+				Block:        number,
+				TxID:         txID,
+				ContractType: tx.GetType(),
+				Status:       tx.GetStatus(),
+				Address:      tron.Address(op.GetAddress()).String(),
+				Amount:       op.GetAmount(),
+			}
+			switch {
+			case isFee[i]:
+				change.Operation = BalanceFee
+			case isTransfer[i]:
+				change.Operation = BalanceTransfer
+			case len(tx.GetTransactionIdentifier()) == 0,
+				tx.GetType() == core.Transaction_Contract_WithdrawBalanceContract.String():
+				change.Operation = BalanceReward
+			case tx.GetType() == core.Transaction_Contract_TriggerSmartContract.String(),
+				tx.GetType() == core.Transaction_Contract_CreateSmartContract.String():
+				change.Operation = BalanceInternal
+			case tx.GetType() == core.Transaction_Contract_TransferContract.String():
+				change.Operation = BalanceTransfer
+			default:
+				change.Operation = BalanceOther
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// BalanceReport TRX balance movement of an address over a block range
+type BalanceReport struct {
+	Address      string
+	StartBalance int64            // at the end of the block preceding the range
+	Deltas       map[string]int64 // SUN by operation
+	Net          int64
+	Expected     int64 // StartBalance + Net
+	Actual       int64 // balance reported by the node at the end block
+	Drift        int64 // Actual - Expected
+	Drifted      bool
+	Changes      []*BalanceChange
+}
+
+// BalanceReconciler accumulates balance traces of a set of addresses
+type BalanceReconciler struct {
+	client    *GrpcClient
+	addresses map[string]bool
+}
+
+// NewBalanceReconciler reconcile the base58 addresses
+func NewBalanceReconciler(g *GrpcClient, addresses ...string) *BalanceReconciler {
+	r := &BalanceReconciler{
+		client:    g,
+		addresses: make(map[string]bool),
+	}
+	for _, addr := range addresses {
+		r.addresses[addr] = true
+	}
+	return r
+}
+
+// Accumulate add the changes of watched addresses into reports
+func (r *BalanceReconciler) Accumulate(reports map[string]*BalanceReport, changes []*BalanceChange) {
+	for _, change := range changes {
+		if !r.addresses[change.Address] {
+			continue
+		}
+		report, ok := reports[change.Address]
+		if !ok {
+			report = &BalanceReport{Address: change.Address, Deltas: make(map[string]int64)}
+			reports[change.Address] = report
+		}
+		report.Deltas[change.Operation] += change.Amount
+		report.Net += change.Amount
+		report.Changes = append(report.Changes, change)
+	}
+}
+
+// Reconcile trace the blocks [start, end] and compare the resulting balances
+// with the balances the node reports at block end
+func (r *BalanceReconciler) Reconcile(start, end int64) ([]*BalanceReport, error) {
+	if start <= 0 || end < start {
+		return nil, fmt.Errorf("invalid block range %d-%d", start, end)
+	}
+	reports := make(map[string]*BalanceReport)
+	for addr := range r.addresses {
+		reports[addr] = &BalanceReport{Address: addr, Deltas: make(map[string]int64)}
+	}
+	for num := start; num <= end; num++ {
+		changes, err := r.client.GetBlockBalanceChanges(num)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %v", num, err)
+		}
+		r.Accumulate(reports, changes)
+	}
+
+	result := make([]*BalanceReport, 0, len(reports))
+	for addr, report := range reports {
+		startBalance, err := r.client.GetAccountBalanceAt(addr, start-1)
+		if err != nil {
+			return nil, fmt.Errorf("%s balance at %d: %v", addr, start-1, err)
+		}
+		endBalance, err := r.client.GetAccountBalanceAt(addr, end)
+		if err != nil {
+			return nil, fmt.Errorf("%s balance at %d: %v", addr, end, err)
+		}
+		report.StartBalance = startBalance
+		report.Expected = startBalance + report.Net
+		report.Actual = endBalance
+		report.Drift = report.Actual - report.Expected
+		report.Drifted = report.Drift != 0
+		result = append(result, report)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Address < result[j].Address })
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyBalanceTrace(t *testing.T) {
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	contract, _ := tron.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	recipient, _ := tron.Base58ToAddress("TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7")
+	witness, _ := tron.Base58ToAddress("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+
+	op := func(addr tron.Address, amount int64) *core.TransactionBalanceTrace_Operation {
+		return &core.TransactionBalanceTrace_Operation{Address: addr, Amount: amount}
+	}
+	trace := &core.BlockBalanceTrace{
+		BlockIdentifier: &core.BlockBalanceTrace_BlockIdentifier{Number: 100},
+		TransactionBalanceTrace: []*core.TransactionBalanceTrace{
+			{
+				TransactionIdentifier: []byte{0x01},
+				Type:                  core.Transaction_Contract_TriggerSmartContract.String(),
+				Status:                "SUCCESS",
+				Operation: []*core.TransactionBalanceTrace_Operation{
+					op(owner, -500),
+					op(contract, 500),
+					op(contract, -200),
+					op(recipient, 200),
+					op(owner, -1000),
+				},
+			},
+			{
+				TransactionIdentifier: []byte{0x02},
+				Type:                  core.Transaction_Contract_TransferContract.String(),
+				Operation: []*core.TransactionBalanceTrace_Operation{
+					op(owner, -300),
+					op(recipient, 300),
+				},
+			},
+			{
+				Type:      "",
+				Operation: []*core.TransactionBalanceTrace_Operation{op(witness, 16)},
+			},
+		},
+	}
+	infos := &api.TransactionInfoList{TransactionInfo: []*core.TransactionInfo{
+		{Id: []byte{0x01}, Fee: 1000},
+	}}
+
+	changes := client.ClassifyBalanceTrace(trace, infos)
+	require.Len(t, changes, 8)
+	operations := make([]string, 0, len(changes))
+	for _, change := range changes {
+		operations = append(operations, change.Operation)
+		assert.Equal(t, int64(100), change.Block)
+	}
+	assert.Equal(t, []string{
+		client.BalanceTransfer, client.BalanceTransfer,
+		client.BalanceInternal, client.BalanceInternal,
+		client.BalanceFee,
+		client.BalanceTransfer, client.BalanceTransfer,
+		client.BalanceReward,
+	}, operations)
+	assert.Equal(t, "01", changes[0].TxID)
+	assert.Equal(t, owner.String(), changes[0].Address)
+
+	reconciler := client.NewBalanceReconciler(nil, owner.String())
+	reports := make(map[string]*client.BalanceReport)
+	reconciler.Accumulate(reports, changes)
+	require.Contains(t, reports, owner.String())
+	report := reports[owner.String()]
+	assert.Equal(t, int64(-1800), report.Net)
+	assert.Equal(t, int64(-800), report.Deltas[client.BalanceTransfer])
+	assert.Equal(t, int64(-1000), report.Deltas[client.BalanceFee])
+	assert.NotContains(t, reports, recipient.String())
+}
@@ -15,6 +15,7 @@ type GrpcClient struct {
 	Address     string
 	Conn        *grpc.ClientConn
 	Client      api.WalletClient
+	Extension   api.WalletExtensionClient
 	grpcTimeout time.Duration
 	opts        []grpc.DialOption
 	apiKey      string
@@ -56,6 +57,7 @@ func (g *GrpcClient) Start(opts ...grpc.DialOption) error {
 		return fmt.Errorf("Connecting GRPC Client: %v", err)
 	}
 	g.Client = api.NewWalletClient(g.Conn)
+	g.Extension = api.NewWalletExtensionClient(g.Conn)
 	return nil
 }
 
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContractView deployed contract settings and runtime
+type ContractView struct {
+	Address                    string // base58
+	Name                       string
+	Origin                     string // base58 deployer, the only account allowed to change settings
+	ConsumeUserResourcePercent int64
+	OriginEnergyLimit          int64
+	CallValue                  int64
+	CodeHash                   string // hex
+	TrxHash                    string // hex of the deployment transaction
+	Version                    int32
+	ABI                        *core.SmartContract_ABI
+	Bytecode                   []byte // creation code
+	Runtime                    []byte // runtime code
+	EnergyUsage                int64
+	EnergyFactor               int64
+	UpdateCycle                int64
+}
+
+// NewContractView convert the node contract info
+func NewContractView(info *core.SmartContractDataWrapper) *ContractView {
+	sc := info.GetSmartContract()
+	return &ContractView{
+		Address:                    tron.Address(sc.GetContractAddress()).String(),
+		Name:                       sc.GetName(),
+		Origin:                     tron.Address(sc.GetOriginAddress()).String(),
+		ConsumeUserResourcePercent: sc.GetConsumeUserResourcePercent(),
+		OriginEnergyLimit:          sc.GetOriginEnergyLimit(),
+		CallValue:                  sc.GetCallValue(),
+		CodeHash:                   common.Bytes2Hex(sc.GetCodeHash()),
+		TrxHash:                    common.Bytes2Hex(sc.GetTrxHash()),
+		Version:                    sc.GetVersion(),
+		ABI:                        sc.GetAbi(),
+		Bytecode:                   sc.GetBytecode(),
+		Runtime:                    info.GetRuntimecode(),
+		EnergyUsage:                info.GetContractState().GetEnergyUsage(),
+		EnergyFactor:               info.GetContractState().GetEnergyFactor(),
+		UpdateCycle:                info.GetContractState().GetUpdateCycle(),
+	}
+}
+
+// GetContractInfo returns contract, runtime code and energy state
+func (g *GrpcClient) GetContractInfo(contractAddress string) (*core.SmartContractDataWrapper, error) {
+	contractDesc, err := tron.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	info, err := g.Client.GetContractInfo(ctx, GetMessageBytes(contractDesc))
+	if err != nil {
+		return nil, err
+	}
+	if len(info.GetSmartContract().GetContractAddress()) == 0 {
+		return nil, fmt.Errorf("contract %s not found", contractAddress)
+	}
+	return info, nil
+}
+
+// GetContractView returns the typed view of a contract
+func (g *GrpcClient) GetContractView(contractAddress string) (*ContractView, error) {
+	info, err := g.GetContractInfo(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return NewContractView(info), nil
+}
+
+// ClearContractABI remove the ABI stored on chain, only the origin can clear it
+func (g *GrpcClient) ClearContractABI(from, contractAddress string) (*api.TransactionExtention, error) {
+	var err error
+	contract := &core.ClearABIContract{}
+	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
+		return nil, err
+	}
+	if contract.ContractAddress, err = common.DecodeCheck(contractAddress); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	tx, err := g.Client.ClearContractABI(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	return tx, nil
+}
+
+// ContractAdmin manage the settings of a deployed contract, changes are
+// checked locally against the contract origin before being built
+type ContractAdmin struct {
+	client  *GrpcClient
+	address string
+}
+
+// NewContractAdmin bind a contract
+func NewContractAdmin(g *GrpcClient, contractAddress string) (*ContractAdmin, error) {
+	if _, err := tron.Base58ToAddress(contractAddress); err != nil {
+		return nil, fmt.Errorf("invalid contract address %s: %v", contractAddress, err)
+	}
+	return &ContractAdmin{client: g, address: contractAddress}, nil
+}
+
+// View returns the current contract view
+func (a *ContractAdmin) View() (*ContractView, error) {
+	return a.client.GetContractView(a.address)
+}
+
+// requireOrigin fail unless from deployed the contract
+func (a *ContractAdmin) requireOrigin(from string) error {
+	view, err := a.View()
+	if err != nil {
+		return err
+	}
+	if view.Origin != from {
+		return fmt.Errorf("%s is not the origin of contract %s (%s)", from, a.address, view.Origin)
+	}
+	return nil
+}
+
+// ClearABI remove the contract ABI
+func (a *ContractAdmin) ClearABI(from string) (*api.TransactionExtention, error) {
+	if err := a.requireOrigin(from); err != nil {
+		return nil, err
+	}
+	return a.client.ClearContractABI(from, a.address)
+}
+
+// UpdateSetting change the percent of energy paid by callers
+func (a *ContractAdmin) UpdateSetting(from string, consumeUserResourcePercent int64) (*api.TransactionExtention, error) {
+	if consumeUserResourcePercent > 100 || consumeUserResourcePercent < 0 {
+		return nil, fmt.Errorf("consume_user_resource_percent should be >= 0 and <= 100")
+	}
+	if err := a.requireOrigin(from); err != nil {
+		return nil, err
+	}
+	return a.client.UpdateSettingContract(from, a.address, consumeUserResourcePercent)
+}
+
+// UpdateEnergyLimit change the energy the origin provides per call
+func (a *ContractAdmin) UpdateEnergyLimit(from string, originEnergyLimit int64) (*api.TransactionExtention, error) {
+	if originEnergyLimit <= 0 {
+		return nil, fmt.Errorf("origin_energy_limit must > 0")
+	}
+	if err := a.requireOrigin(from); err != nil {
+		return nil, err
+	}
+	return a.client.UpdateEnergyLimitContract(from, a.address, originEnergyLimit)
+}
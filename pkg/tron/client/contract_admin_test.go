@@ -0,0 +1,38 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContractView(t *testing.T) {
+	origin, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	contract, _ := tron.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	view := client.NewContractView(&core.SmartContractDataWrapper{
+		SmartContract: &core.SmartContract{
+			OriginAddress:              origin,
+			ContractAddress:            contract,
+			Name:                       "TetherToken",
+			ConsumeUserResourcePercent: 30,
+			OriginEnergyLimit:          10000000,
+			CodeHash:                   []byte{0xca, 0xfe},
+		},
+		Runtimecode:   []byte{0x60, 0x80},
+		ContractState: &core.ContractState{EnergyUsage: 5, EnergyFactor: 1000},
+	})
+	assert.Equal(t, contract.String(), view.Address)
+	assert.Equal(t, origin.String(), view.Origin)
+	assert.Equal(t, int64(30), view.ConsumeUserResourcePercent)
+	assert.Equal(t, int64(10000000), view.OriginEnergyLimit)
+	assert.Equal(t, "cafe", view.CodeHash)
+	assert.Equal(t, []byte{0x60, 0x80}, view.Runtime)
+	assert.Equal(t, int64(1000), view.EnergyFactor)
+
+	_, err := client.NewContractAdmin(nil, "invalid")
+	assert.NotNil(t, err)
+}
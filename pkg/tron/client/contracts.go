@@ -124,17 +124,24 @@ func (g *GrpcClient) triggerConstantContract(ct *core.TriggerSmartContract) (*ap
 // TriggerContract and return tx result
 func (g *GrpcClient) TriggerContract(from, contractAddress, method, jsonString string,
 	feeLimit, tAmount int64, tTokenID string, tTokenAmount int64) (*api.TransactionExtention, error) {
-	fromDesc, err := tron.Base58ToAddress(from)
+	param, err := abi.LoadFromJSON(jsonString)
 	if err != nil {
 		return nil, err
 	}
 
-	contractDesc, err := tron.Base58ToAddress(contractAddress)
+	return g.TriggerContractWithParam(from, contractAddress, method, param,
+		feeLimit, tAmount, tTokenID, tTokenAmount)
+}
+
+// TriggerContractWithParam and return tx result
+func (g *GrpcClient) TriggerContractWithParam(from, contractAddress, method string, param []abi.Param,
+	feeLimit, tAmount int64, tTokenID string, tTokenAmount int64) (*api.TransactionExtention, error) {
+	fromDesc, err := tron.Base58ToAddress(from)
 	if err != nil {
 		return nil, err
 	}
 
-	param, err := abi.LoadFromJSON(jsonString)
+	contractDesc, err := tron.Base58ToAddress(contractAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -280,6 +287,10 @@ func (g *GrpcClient) DeployContract(from, contractName string,
 		},
 	}
 
+	return g.deployContract(ct, feeLimit)
+}
+
+func (g *GrpcClient) deployContract(ct *core.CreateSmartContract, feeLimit int64) (*api.TransactionExtention, error) {
 	ctx, cancel := g.getContext()
 	defer cancel()
 
@@ -330,3 +341,80 @@ func (g *GrpcClient) GetContractABI(contractAddress string) (*core.SmartContract
 
 	return sm.Abi, nil
 }
+
+// CallContract make a constant call to method (name or full signature) and unpack
+// the result using the contract ABI
+func (g *GrpcClient) CallContract(contractAddress, method string, param []abi.Param) ([]interface{}, error) {
+	contractABI, err := g.GetContractABI(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return g.CallContractWithABI(contractABI, contractAddress, method, param)
+}
+
+// CallContractWithABI make a constant call using a known contract ABI
+func (g *GrpcClient) CallContractWithABI(contractABI *core.SmartContract_ABI, contractAddress, method string, param []abi.Param) ([]interface{}, error) {
+	entry, err := abi.GetMethodEntry(contractABI, method)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := abi.EntrySignature(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	contractDesc, err := tron.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	dataBytes, err := abi.Pack(signature, param)
+	if err != nil {
+		return nil, err
+	}
+
+	ct := &core.TriggerSmartContract{
+		OwnerAddress:    tron.HexToAddress("410000000000000000000000000000000000000000"),
+		ContractAddress: contractDesc.Bytes(),
+		Data:            dataBytes,
+	}
+
+	tx, err := g.triggerConstantContract(ct)
+	if err != nil {
+		return nil, err
+	}
+	if err := constantCallError(tx); err != nil {
+		return nil, err
+	}
+	if len(tx.GetConstantResult()) == 0 {
+		return nil, fmt.Errorf("%s: empty constant result", signature)
+	}
+	return abi.Unpack(contractABI, signature, tx.GetConstantResult()[0])
+}
+
+// constantCallError extract revert reason from a failed constant call
+func constantCallError(tx *api.TransactionExtention) error {
+	reverted := len(tx.GetTransaction().GetRet()) > 0 &&
+		tx.GetTransaction().GetRet()[0].GetContractRet() == core.Transaction_Result_REVERT
+	if tx.GetResult().GetCode() == api.Return_SUCCESS && !reverted {
+		return nil
+	}
+	if len(tx.GetConstantResult()) > 0 {
+		if err := abi.UnpackRevert(tx.GetConstantResult()[0]); err != nil {
+			return err
+		}
+	}
+	if len(tx.GetResult().GetMessage()) > 0 {
+		return fmt.Errorf("%s", string(tx.GetResult().GetMessage()))
+	}
+	return fmt.Errorf("execution reverted")
+}
+
+// DecodeContractCall return method name and arguments of a TriggerSmartContract
+func (g *GrpcClient) DecodeContractCall(ct *core.TriggerSmartContract) (string, []interface{}, error) {
+	contractABI, err := g.GetContractABI(tron.Address(ct.GetContractAddress()).String())
+	if err != nil {
+		return "", nil, err
+	}
+	return abi.DecodeInput(contractABI, ct.GetData())
+}
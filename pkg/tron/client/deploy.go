@@ -0,0 +1,249 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeployOptions of a compiled contract deployment
+type DeployOptions struct {
+	Name                       string // defaults to the solc contract name
+	FeeLimit                   int64
+	ConsumeUserResourcePercent int64
+	OriginEnergyLimit          int64
+	CallValue                  int64 // SUN sent to a payable constructor
+	TokenID                    string
+	TokenValue                 int64
+	// Libraries base58 address by library name (file:Name)
+	Libraries       map[string]string
+	ConstructorArgs []abi.Param
+}
+
+// DeployedContract expected result of a deployment
+type DeployedContract struct {
+	TxID     string
+	Address  string // base58
+	Owner    string // base58
+	Bytecode []byte // linked creation code with constructor arguments
+	// RuntimeBytecode linked code stored on chain, the ranges of Immutables
+	// are set by the constructor and not compared. Immutables is nil when the
+	// compiler output does not report them (combined-json).
+	RuntimeBytecode []byte
+	Immutables      []abi.ImmutableReference
+}
+
+// ContractAddress returns the address of the contract created by a
+// CreateSmartContract transaction: keccak256(txID || owner), last 20 bytes
+func ContractAddress(tx *core.Transaction) (string, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) == 0 || contracts[0].GetType() != core.Transaction_Contract_CreateSmartContract {
+		return "", fmt.Errorf("not a contract creation transaction")
+	}
+	ct := &core.CreateSmartContract{}
+	if err := contracts[0].GetParameter().UnmarshalTo(ct); err != nil {
+		return "", err
+	}
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return "", err
+	}
+	txID := sha256.Sum256(rawData)
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(txID[:])
+	hasher.Write(ct.GetOwnerAddress())
+	hash := hasher.Sum(nil)
+	address := append([]byte{tron.TronBytePrefix}, hash[len(hash)-20:]...)
+	return tron.Address(address).String(), nil
+}
+
+// DeploySolcContract deploy a contract compiled by solc, linking libraries and
+// appending the encoded constructor arguments
+func (g *GrpcClient) DeploySolcContract(from string, contract *abi.SolcContract, opts DeployOptions) (*api.TransactionExtention, *DeployedContract, error) {
+	owner, err := common.DecodeCheck(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.ConsumeUserResourcePercent > 100 || opts.ConsumeUserResourcePercent < 0 {
+		return nil, nil, fmt.Errorf("consume_user_resource_percent should be >= 0 and <= 100")
+	}
+	if opts.OriginEnergyLimit <= 0 {
+		return nil, nil, fmt.Errorf("origin_energy_limit must > 0")
+	}
+	if opts.CallValue < 0 || opts.TokenValue < 0 {
+		return nil, nil, fmt.Errorf("invalid call value %d/%d", opts.CallValue, opts.TokenValue)
+	}
+	constructor := abi.GetConstructorEntry(contract.ABI)
+	if (opts.CallValue > 0 || opts.TokenValue > 0) && !constructor.GetPayable() &&
+		constructor.GetStateMutability() != core.SmartContract_ABI_Entry_Payable {
+		return nil, nil, fmt.Errorf("constructor of %s is not payable", contract.Name)
+	}
+
+	linked, err := abi.LinkBytecode(contract.Bytecode, opts.Libraries)
+	if err != nil {
+		return nil, nil, err
+	}
+	bytecode, err := common.FromHex(linked)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid bytecode: %v", err)
+	}
+	if len(bytecode) == 0 {
+		return nil, nil, fmt.Errorf("contract %s has no bytecode", contract.Name)
+	}
+	linkedRuntime, err := abi.LinkBytecode(contract.RuntimeBytecode, opts.Libraries)
+	if err != nil {
+		return nil, nil, err
+	}
+	runtime, err := common.FromHex(linkedRuntime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid runtime bytecode: %v", err)
+	}
+	args, err := abi.PackConstructor(contract.ABI, opts.ConstructorArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	bytecode = append(bytecode, args...)
+
+	name := opts.Name
+	if name == "" {
+		name = contract.Name[strings.LastIndex(contract.Name, ":")+1:]
+	}
+	ct := &core.CreateSmartContract{
+		OwnerAddress: owner,
+		NewContract: &core.SmartContract{
+			OriginAddress:              owner,
+			Abi:                        contract.ABI,
+			Name:                       name,
+			ConsumeUserResourcePercent: opts.ConsumeUserResourcePercent,
+			OriginEnergyLimit:          opts.OriginEnergyLimit,
+			Bytecode:                   bytecode,
+			CallValue:                  opts.CallValue,
+		},
+	}
+	if opts.TokenID != "" {
+		if ct.TokenId, err = strconv.ParseInt(opts.TokenID, 10, 64); err != nil {
+			return nil, nil, fmt.Errorf("invalid token id %s: %v", opts.TokenID, err)
+		}
+		ct.CallTokenValue = opts.TokenValue
+	}
+
+	tx, err := g.deployContract(ct, opts.FeeLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	address, err := ContractAddress(tx.GetTransaction())
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, &DeployedContract{
+		TxID:            common.Bytes2Hex(tx.GetTxid()),
+		Address:         address,
+		Owner:           from,
+		Bytecode:        bytecode,
+		RuntimeBytecode: runtime,
+		Immutables:      contract.ImmutableReferences,
+	}, nil
+}
+
+// VerifyDeployment check a confirmed deployment created the expected contract
+// with the expected code
+func (g *GrpcClient) VerifyDeployment(deployed *DeployedContract) error {
+	info, err := g.GetTransactionInfoByID(deployed.TxID)
+	if err != nil {
+		return fmt.Errorf("deployment %s not confirmed: %v", deployed.TxID, err)
+	}
+	if info.GetReceipt().GetResult() != core.Transaction_Result_SUCCESS {
+		return fmt.Errorf("deployment %s failed: %s %s", deployed.TxID,
+			info.GetReceipt().GetResult(), string(info.GetResMessage()))
+	}
+	if address := tron.Address(info.GetContractAddress()).String(); address != deployed.Address {
+		return fmt.Errorf("deployment created %s, expected %s", address, deployed.Address)
+	}
+
+	contractAddress, err := common.DecodeCheck(deployed.Address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	sm, err := g.Client.GetContract(ctx, GetMessageBytes(contractAddress))
+	if err != nil {
+		return err
+	}
+	if tron.Address(sm.GetOriginAddress()).String() != deployed.Owner {
+		return fmt.Errorf("contract %s origin is %s, expected %s", deployed.Address,
+			tron.Address(sm.GetOriginAddress()).String(), deployed.Owner)
+	}
+	if len(deployed.RuntimeBytecode) == 0 {
+		return fmt.Errorf("contract %s: no runtime bytecode to compare", deployed.Address)
+	}
+	if !matchRuntimeCode(sm.GetBytecode(), deployed.RuntimeBytecode, deployed.Immutables) {
+		if deployed.Immutables == nil && onlyImmutablesDiffer(sm.GetBytecode(), deployed.RuntimeBytecode) {
+			return fmt.Errorf("contract %s code differs in zeroed ranges of the runtime bytecode: "+
+				"immutables require the solc standard-json output to be verified", deployed.Address)
+		}
+		return fmt.Errorf("contract %s code does not match the runtime bytecode", deployed.Address)
+	}
+	return nil
+}
+
+// onlyImmutablesDiffer returns true when code differs from the expected runtime
+// code only in 32 byte words left zero by the compiler, where immutables go
+func onlyImmutablesDiffer(code, expected []byte) bool {
+	if len(code) != len(expected) {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] == expected[i] {
+			continue
+		}
+		// the zero word around i
+		start, end := i, i
+		for start > 0 && expected[start-1] == 0 {
+			start--
+		}
+		for end < len(expected) && expected[end] == 0 {
+			end++
+		}
+		if end-start < 32 {
+			return false
+		}
+		i = end - 1
+	}
+	return true
+}
+
+// matchRuntimeCode compare code on chain with the expected runtime code,
+// ignoring the immutable ranges filled by the constructor
+func matchRuntimeCode(code, expected []byte, immutables []abi.ImmutableReference) bool {
+	if len(code) != len(expected) {
+		return false
+	}
+	masked := append([]byte{}, code...)
+	for _, ref := range immutables {
+		if ref.Start < 0 || ref.Length < 0 || ref.Start+ref.Length > len(masked) {
+			return false
+		}
+		copy(masked[ref.Start:ref.Start+ref.Length], expected[ref.Start:ref.Start+ref.Length])
+	}
+	return bytes.Equal(masked, expected)
+}
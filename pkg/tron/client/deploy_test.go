@@ -0,0 +1,128 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestContractAddress(t *testing.T) {
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	param, err := anypb.New(&core.CreateSmartContract{
+		OwnerAddress: owner,
+		NewContract:  &core.SmartContract{OriginAddress: owner, Bytecode: []byte{0x60, 0x80}},
+	})
+	require.Nil(t, err)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		Timestamp: 1600000000000,
+		Contract:  []*core.Transaction_Contract{{Type: core.Transaction_Contract_CreateSmartContract, Parameter: param}},
+	}}
+
+	rawData, err := proto.Marshal(tx.GetRawData())
+	require.Nil(t, err)
+	txID := sha256.Sum256(rawData)
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(txID[:])
+	hasher.Write(owner)
+	expected := append([]byte{0x41}, hasher.Sum(nil)[12:]...)
+
+	address, err := client.ContractAddress(tx)
+	require.Nil(t, err)
+	assert.Equal(t, tron.Address(expected).String(), address)
+
+	_, err = client.ContractAddress(&core.Transaction{RawData: &core.TransactionRaw{}})
+	assert.NotNil(t, err)
+}
+
+// solc standard json output of a contract with an immutable owner
+const immutableOutput = `{"contracts":{"Vault.sol":{"Vault":{
+"abi":[{"inputs":[],"stateMutability":"nonpayable","type":"constructor"}],
+"evm":{
+"bytecode":{"object":"60a060405234801561001057600080fd5b503360805260805160601c6080f3fe"},
+"deployedBytecode":{"object":"6080604052600436107f000000000000000000000000000000000000000000000000000000000000000000","immutableReferences":{"3":[{"start":10,"length":32}]}}
+}}}}}`
+
+// deployNode serves the receipt and the stored code of one deployment
+type deployNode struct {
+	api.WalletClient
+	info     *core.TransactionInfo
+	contract *core.SmartContract
+}
+
+func (n *deployNode) GetTransactionInfoById(context.Context, *api.BytesMessage, ...grpc.CallOption) (*core.TransactionInfo, error) {
+	return n.info, nil
+}
+
+func (n *deployNode) GetContract(context.Context, *api.BytesMessage, ...grpc.CallOption) (*core.SmartContract, error) {
+	return n.contract, nil
+}
+
+func TestVerifyDeployment(t *testing.T) {
+	contracts, err := abi.ParseSolcStandardJSON([]byte(immutableOutput))
+	require.Nil(t, err)
+	vault := contracts["Vault.sol:Vault"]
+	assert.Equal(t, []abi.ImmutableReference{{Start: 10, Length: 32}}, vault.ImmutableReferences)
+
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	address, _ := tron.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	creation, err := common.FromHex(vault.Bytecode)
+	require.Nil(t, err)
+	runtime, err := common.FromHex(vault.RuntimeBytecode)
+	require.Nil(t, err)
+	// the node stores the runtime code with the immutable owner set
+	stored := append([]byte{}, runtime...)
+	copy(stored[10+12:], owner[1:])
+
+	node := &deployNode{
+		info: &core.TransactionInfo{
+			Id:              []byte{0x01},
+			ContractAddress: address,
+			Receipt:         &core.ResourceReceipt{Result: core.Transaction_Result_SUCCESS},
+		},
+		contract: &core.SmartContract{OriginAddress: owner, Bytecode: stored},
+	}
+	g := client.NewGrpcClient("")
+	g.Client = node
+	deployed := &client.DeployedContract{
+		TxID:            "01",
+		Address:         address.String(),
+		Owner:           owner.String(),
+		Bytecode:        creation,
+		RuntimeBytecode: runtime,
+		Immutables:      vault.ImmutableReferences,
+	}
+	assert.Nil(t, g.VerifyDeployment(deployed))
+
+	// code outside the immutable ranges must match
+	stored[0] = 0x61
+	assert.NotNil(t, g.VerifyDeployment(deployed))
+	stored[0] = 0x60
+
+	// combined-json output does not report the immutables
+	deployed.Immutables = nil
+	err = g.VerifyDeployment(deployed)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "standard-json")
+	stored[0] = 0x61
+	err = g.VerifyDeployment(deployed)
+	require.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "standard-json")
+	stored[0] = 0x60
+	deployed.Immutables = vault.ImmutableReferences
+
+	node.contract.Bytecode = creation
+	assert.NotNil(t, g.VerifyDeployment(deployed))
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/EntySquare/chain-util/pkg/tron/common/numeric"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+)
+
+// exchangeSupply virtual relay supply of the TRON bancor formula
+const exchangeSupply = 1_000_000_000_000_000_000
+
+// ExchangeQuote expected result of a trade against an exchange
+type ExchangeQuote struct {
+	ExchangeID     int64
+	SellTokenID    string
+	SellQuantity   int64
+	BuyTokenID     string
+	BuyQuantity    int64
+	SpotPrice      numeric.Dec // buy token per sell token before the trade
+	ExecutionPrice numeric.Dec // buy token per sell token of this trade
+	PriceImpact    numeric.Dec // relative loss of execution price against spot price
+}
+
+// exchangeSides returns the balances of the exchange ordered as (tokenID, other)
+func exchangeSides(exchange *core.Exchange, tokenID string) (int64, string, int64, error) {
+	if exchange == nil {
+		return 0, "", 0, fmt.Errorf("invalid exchange")
+	}
+	switch tokenID {
+	case string(exchange.GetFirstTokenId()):
+		return exchange.GetFirstTokenBalance(), string(exchange.GetSecondTokenId()), exchange.GetSecondTokenBalance(), nil
+	case string(exchange.GetSecondTokenId()):
+		return exchange.GetSecondTokenBalance(), string(exchange.GetFirstTokenId()), exchange.GetFirstTokenBalance(), nil
+	}
+	return 0, "", 0, fmt.Errorf("token %s not in exchange %d", tokenID, exchange.GetExchangeId())
+}
+
+// ExchangeBuyQuantity returns the amount received selling quant against the
+// reserves, following the node implementation (relay token with 1e18 supply)
+func ExchangeBuyQuantity(sellBalance, buyBalance, quant int64) int64 {
+	supply := float64(exchangeSupply)
+
+	// sell token to relay
+	newBalance := sellBalance + quant
+	relay := int64(-supply * (1.0 - math.Pow(1.0+float64(quant)/float64(newBalance), 0.0005)))
+
+	// relay to buy token, supply is back to its initial value
+	return int64(float64(buyBalance) * (math.Pow(1.0+float64(relay)/supply, 2000.0) - 1.0))
+}
+
+// ExchangeSpotPrice returns the amount of the other token per unit of tokenID
+func ExchangeSpotPrice(exchange *core.Exchange, tokenID string) (numeric.Dec, error) {
+	balance, _, otherBalance, err := exchangeSides(exchange, tokenID)
+	if err != nil {
+		return numeric.Dec{}, err
+	}
+	if balance <= 0 {
+		return numeric.Dec{}, fmt.Errorf("exchange %d has no %s reserve", exchange.GetExchangeId(), tokenID)
+	}
+	return numeric.NewDec(otherBalance).Quo(numeric.NewDec(balance)), nil
+}
+
+// ExchangeQuoteTrade quote selling quant of tokenID against the exchange reserves
+func ExchangeQuoteTrade(exchange *core.Exchange, tokenID string, quant int64) (*ExchangeQuote, error) {
+	if quant <= 0 {
+		return nil, fmt.Errorf("invalid trade quantity %d", quant)
+	}
+	balance, otherID, otherBalance, err := exchangeSides(exchange, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	spot, err := ExchangeSpotPrice(exchange, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	buy := ExchangeBuyQuantity(balance, otherBalance, quant)
+	if buy <= 0 {
+		return nil, fmt.Errorf("trade of %d %s returns nothing", quant, tokenID)
+	}
+	if buy >= otherBalance {
+		return nil, fmt.Errorf("exchange %d has not enough %s", exchange.GetExchangeId(), otherID)
+	}
+
+	execution := numeric.NewDec(buy).Quo(numeric.NewDec(quant))
+	impact := numeric.ZeroDec()
+	if spot.IsPositive() {
+		impact = numeric.OneDec().Sub(execution.Quo(spot))
+	}
+	return &ExchangeQuote{
+		ExchangeID:     exchange.GetExchangeId(),
+		SellTokenID:    tokenID,
+		SellQuantity:   quant,
+		BuyTokenID:     otherID,
+		BuyQuantity:    buy,
+		SpotPrice:      spot,
+		ExecutionPrice: execution,
+		PriceImpact:    impact,
+	}, nil
+}
+
+// ExchangeMinimumExpected returns the expected amount accepted by a trade
+// for a slippage tolerance (0.01 is 1%), never below 1
+func ExchangeMinimumExpected(buyQuantity int64, slippage numeric.Dec) (int64, error) {
+	if slippage.IsNil() || slippage.IsNegative() || slippage.GTE(numeric.OneDec()) {
+		return 0, fmt.Errorf("invalid slippage %v", slippage)
+	}
+	expected := numeric.NewDec(buyQuantity).Mul(numeric.OneDec().Sub(slippage)).TruncateInt64()
+	if expected < 1 {
+		expected = 1
+	}
+	return expected, nil
+}
+
+// ExchangeInjectQuote returns the amount of the other token the node takes
+// when injecting quant of tokenID, keeping the reserve ratio
+func ExchangeInjectQuote(exchange *core.Exchange, tokenID string, quant int64) (string, int64, error) {
+	if quant <= 0 {
+		return "", 0, fmt.Errorf("invalid inject quantity %d", quant)
+	}
+	balance, otherID, otherBalance, err := exchangeSides(exchange, tokenID)
+	if err != nil {
+		return "", 0, err
+	}
+	if balance <= 0 || otherBalance <= 0 {
+		return "", 0, fmt.Errorf("exchange %d is empty", exchange.GetExchangeId())
+	}
+	other := new(big.Int).Mul(big.NewInt(otherBalance), big.NewInt(quant))
+	other.Quo(other, big.NewInt(balance))
+	if !other.IsInt64() || other.Sign() <= 0 {
+		return "", 0, fmt.Errorf("inject quantity %d %s is out of range", quant, tokenID)
+	}
+	return otherID, other.Int64(), nil
+}
+
+// ExchangeWithdrawQuote returns the amount of the other token received when
+// withdrawing quant of tokenID, the node rejects withdrawals losing more than
+// 0.01% to rounding
+func ExchangeWithdrawQuote(exchange *core.Exchange, tokenID string, quant int64) (string, int64, error) {
+	if quant <= 0 {
+		return "", 0, fmt.Errorf("invalid withdraw quantity %d", quant)
+	}
+	balance, otherID, otherBalance, err := exchangeSides(exchange, tokenID)
+	if err != nil {
+		return "", 0, err
+	}
+	if quant > balance {
+		return "", 0, fmt.Errorf("exchange %d has not enough %s", exchange.GetExchangeId(), tokenID)
+	}
+	exact := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(otherBalance), big.NewInt(quant)),
+		big.NewInt(balance),
+	)
+	other := new(big.Int).Quo(exact.Num(), exact.Denom())
+	if other.Sign() <= 0 {
+		return "", 0, fmt.Errorf("withdraw quantity %d %s is too small", quant, tokenID)
+	}
+	remainder, _ := new(big.Rat).Sub(exact, new(big.Rat).SetInt(other)).Float64()
+	if remainder/float64(other.Int64()) > 0.0001 {
+		return "", 0, fmt.Errorf("withdraw quantity %d %s is not precise enough", quant, tokenID)
+	}
+	return otherID, other.Int64(), nil
+}
+
+// ExchangeTradeWithSlippage quote the trade against the current reserves and
+// build an ExchangeTrade whose expected amount allows the slippage tolerance
+func (g *GrpcClient) ExchangeTradeWithSlippage(
+	from string,
+	exchangeID int64,
+	tokenID string,
+	amountToken int64,
+	slippage numeric.Dec,
+) (*api.TransactionExtention, *ExchangeQuote, error) {
+	exchange, err := g.ExchangeByID(exchangeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	quote, err := ExchangeQuoteTrade(exchange, tokenID, amountToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	expected, err := ExchangeMinimumExpected(quote.BuyQuantity, slippage)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := g.ExchangeTrade(from, exchangeID, tokenID, amountToken, expected)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, quote, nil
+}
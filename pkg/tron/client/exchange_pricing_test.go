@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/common/numeric"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangeQuoteTrade(t *testing.T) {
+	exchange := &core.Exchange{
+		ExchangeId:         1,
+		FirstTokenId:       []byte("1000001"),
+		FirstTokenBalance:  1000000,
+		SecondTokenId:      []byte("_"),
+		SecondTokenBalance: 2000000,
+	}
+
+	spot, err := client.ExchangeSpotPrice(exchange, "1000001")
+	require.Nil(t, err)
+	assert.Equal(t, "2.000000000000000000", spot.String())
+
+	// bancor with equal weights behaves as a constant product
+	quote, err := client.ExchangeQuoteTrade(exchange, "1000001", 1000)
+	require.Nil(t, err)
+	assert.Equal(t, "_", quote.BuyTokenID)
+	assert.InDelta(t, 2000000*1000/1001000, quote.BuyQuantity, 1)
+	assert.True(t, quote.PriceImpact.IsPositive())
+	assert.True(t, quote.PriceImpact.LT(numeric.MustNewDecFromStr("0.002")))
+
+	large, err := client.ExchangeQuoteTrade(exchange, "1000001", 1000000)
+	require.Nil(t, err)
+	assert.InDelta(t, 1000000, large.BuyQuantity, 1)
+	assert.True(t, large.PriceImpact.GT(quote.PriceImpact))
+
+	_, err = client.ExchangeQuoteTrade(exchange, "1000002", 1000)
+	assert.NotNil(t, err)
+
+	expected, err := client.ExchangeMinimumExpected(1000, numeric.MustNewDecFromStr("0.01"))
+	require.Nil(t, err)
+	assert.Equal(t, int64(990), expected)
+	_, err = client.ExchangeMinimumExpected(1000, numeric.MustNewDecFromStr("1"))
+	assert.NotNil(t, err)
+}
+
+func TestExchangeInjectWithdrawQuote(t *testing.T) {
+	exchange := &core.Exchange{
+		FirstTokenId:       []byte("1000001"),
+		FirstTokenBalance:  1000000,
+		SecondTokenId:      []byte("_"),
+		SecondTokenBalance: 3000000,
+	}
+	other, quant, err := client.ExchangeInjectQuote(exchange, "1000001", 100)
+	require.Nil(t, err)
+	assert.Equal(t, "_", other)
+	assert.Equal(t, int64(300), quant)
+
+	other, quant, err = client.ExchangeWithdrawQuote(exchange, "_", 300)
+	require.Nil(t, err)
+	assert.Equal(t, "1000001", other)
+	assert.Equal(t, int64(100), quant)
+
+	// 1 * 1000000 / 3000000 rounds down to zero
+	_, _, err = client.ExchangeWithdrawQuote(exchange, "_", 1)
+	assert.NotNil(t, err)
+	// 3001 / 3 loses more than 0.01% to rounding
+	_, _, err = client.ExchangeWithdrawQuote(exchange, "_", 3001)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,339 @@
+package client
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrWalletExtensionUnavailable the node does not serve the WalletExtension
+// service, it must run with the transaction history index enabled
+var ErrWalletExtensionUnavailable = errors.New("node does not provide the WalletExtension service")
+
+// History directions
+const (
+	HistoryAll      = ""
+	HistoryOutgoing = "OUT"
+	HistoryIncoming = "IN"
+)
+
+// historyPageSize default and maximum page size accepted by the node
+const historyPageSize = 50
+
+// HistoryOptions filters of AccountHistory
+type HistoryOptions struct {
+	Direction string
+	// ContractTypes keep only these contract types, all when empty
+	ContractTypes []core.Transaction_Contract_ContractType
+	// TRC20 keep only calls to this base58 token contract. The node indexes
+	// incoming transactions by contract recipient, so TRC20 tokens received
+	// by the account are not part of its history
+	TRC20    string
+	PageSize int64
+	Cursor   HistoryCursor
+}
+
+// HistoryCursor position in the account history, save it to resume iteration
+type HistoryCursor struct {
+	Outgoing HistoryPosition
+	Incoming HistoryPosition
+}
+
+// HistoryPosition last transaction read in one direction. The history is
+// listed newest first, so transactions received since shift the older ones:
+// iteration resumes strictly after TxID, Offset is only where to look for it.
+type HistoryPosition struct {
+	Block  int64
+	TxID   string
+	Offset int64
+	Done   bool
+}
+
+// HistoryEntry decoded transaction of the account history. Err is set for
+// transactions that cannot be decoded, they only carry ID, Direction,
+// Timestamp and Raw and are returned whatever the filters.
+type HistoryEntry struct {
+	ID        string
+	Direction string
+	Type      string
+	Owner     string
+	To        string
+	Contract  string
+	TokenID   string
+	Amount    *big.Int
+	Timestamp time.Time
+	Result    string
+	Raw       *api.TransactionExtention
+	Err       error
+}
+
+// AccountHistoryIterator pages through the account history, outgoing
+// transactions first then incoming ones, newest first in each direction
+type AccountHistoryIterator struct {
+	client  *GrpcClient
+	account []byte
+	opts    HistoryOptions
+	types   map[core.Transaction_Contract_ContractType]bool
+	cursor  HistoryCursor
+}
+
+// AccountHistory returns an iterator over the transactions of addr
+func (g *GrpcClient) AccountHistory(addr string, opts HistoryOptions) (*AccountHistoryIterator, error) {
+	account, err := common.DecodeCheck(addr)
+	if err != nil {
+		return nil, err
+	}
+	switch opts.Direction {
+	case HistoryAll, HistoryOutgoing, HistoryIncoming:
+	default:
+		return nil, fmt.Errorf("invalid history direction %s", opts.Direction)
+	}
+	if opts.PageSize <= 0 || opts.PageSize > historyPageSize {
+		opts.PageSize = historyPageSize
+	}
+	if opts.TRC20 != "" {
+		if _, err := common.DecodeCheck(opts.TRC20); err != nil {
+			return nil, fmt.Errorf("invalid TRC20 contract %s: %v", opts.TRC20, err)
+		}
+	}
+
+	it := &AccountHistoryIterator{
+		client:  g,
+		account: account,
+		opts:    opts,
+		types:   make(map[core.Transaction_Contract_ContractType]bool),
+		cursor:  opts.Cursor,
+	}
+	for _, t := range opts.ContractTypes {
+		it.types[t] = true
+	}
+	if opts.Direction == HistoryIncoming {
+		it.cursor.Outgoing.Done = true
+	}
+	if opts.Direction == HistoryOutgoing {
+		it.cursor.Incoming.Done = true
+	}
+	return it, nil
+}
+
+// Cursor returns the position after the last page
+func (it *AccountHistoryIterator) Cursor() HistoryCursor {
+	return it.cursor
+}
+
+// Done returns true once every page was read
+func (it *AccountHistoryIterator) Done() bool {
+	return it.cursor.Outgoing.Done && it.cursor.Incoming.Done
+}
+
+// Next returns the next non empty page of entries, nil when done
+func (it *AccountHistoryIterator) Next() ([]*HistoryEntry, error) {
+	for !it.Done() {
+		entries, err := it.page()
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			return entries, nil
+		}
+	}
+	return nil, nil
+}
+
+// page read a single page of the current direction
+func (it *AccountHistoryIterator) page() ([]*HistoryEntry, error) {
+	direction := HistoryOutgoing
+	position := &it.cursor.Outgoing
+	if position.Done {
+		direction = HistoryIncoming
+		position = &it.cursor.Incoming
+	}
+
+	// read again the last transaction to check nothing was inserted before it
+	offset := position.Offset
+	if position.TxID != "" && offset > 0 {
+		offset--
+	}
+	list, err := it.client.getTransactionsPage(it.account, direction, offset, it.opts.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	txs := list.GetTransaction()
+	if len(txs) == 0 {
+		position.Done = true
+		return nil, nil
+	}
+
+	start, err := it.resumeIndex(txs, position)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*HistoryEntry, 0, len(txs))
+	for _, tx := range txs[start:] {
+		entry, err := NewHistoryEntry(tx, direction)
+		if err != nil {
+			// report it and move past it, a resumed iteration would meet it again
+			entries = append(entries, &HistoryEntry{
+				ID:        historyTxID(tx),
+				Direction: direction,
+				Timestamp: historyTimestamp(tx),
+				Raw:       tx,
+				Err:       err,
+			})
+			continue
+		}
+		if it.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	if start < len(txs) {
+		last := historyTxID(txs[len(txs)-1])
+		if position.Block, err = it.client.transactionBlock(last); err != nil {
+			return nil, err
+		}
+		position.TxID = last
+	}
+	position.Offset = offset + int64(len(txs))
+	position.Done = int64(len(txs)) < it.opts.PageSize
+	return entries, nil
+}
+
+// resumeIndex returns the index of the first transaction of txs after position,
+// len(txs) when every one of them was already read
+func (it *AccountHistoryIterator) resumeIndex(txs []*api.TransactionExtention, position *HistoryPosition) (int, error) {
+	if position.TxID == "" {
+		return 0, nil
+	}
+	for i, tx := range txs {
+		if historyTxID(tx) == position.TxID {
+			return i + 1, nil
+		}
+	}
+	// newer transactions until the page ends in a block not after the last one
+	block, err := it.client.transactionBlock(historyTxID(txs[len(txs)-1]))
+	if err != nil {
+		return 0, err
+	}
+	if block > position.Block {
+		return len(txs), nil
+	}
+	// the last transaction is gone (block reorganisation), resume by block
+	for i, tx := range txs {
+		block, err := it.client.transactionBlock(historyTxID(tx))
+		if err != nil {
+			return 0, err
+		}
+		if block < position.Block {
+			return i, nil
+		}
+	}
+	return len(txs), nil
+}
+
+// historyTxID returns the hex id of a history transaction
+func historyTxID(tx *api.TransactionExtention) string {
+	if len(tx.GetTxid()) > 0 {
+		return common.Bytes2Hex(tx.GetTxid())
+	}
+	rawData, err := proto.Marshal(tx.GetTransaction().GetRawData())
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(rawData)
+	return common.Bytes2Hex(hash[:])
+}
+
+// historyTimestamp returns the creation time of a history transaction
+func historyTimestamp(tx *api.TransactionExtention) time.Time {
+	return time.Unix(0, tx.GetTransaction().GetRawData().GetTimestamp()*int64(time.Millisecond))
+}
+
+// transactionBlock returns the block number of a confirmed transaction
+func (g *GrpcClient) transactionBlock(id string) (int64, error) {
+	info, err := g.GetTransactionInfoByID(id)
+	if err != nil {
+		return 0, fmt.Errorf("transaction %s: %w", id, err)
+	}
+	return info.GetBlockNumber(), nil
+}
+
+// Match returns true when entry passes the iterator filters
+func (it *AccountHistoryIterator) Match(entry *HistoryEntry) bool {
+	if len(it.types) > 0 {
+		contractType, ok := core.Transaction_Contract_ContractType_value[entry.Type]
+		if !ok || !it.types[core.Transaction_Contract_ContractType(contractType)] {
+			return false
+		}
+	}
+	if it.opts.TRC20 != "" {
+		return entry.Type == core.Transaction_Contract_TriggerSmartContract.String() && entry.Contract == it.opts.TRC20
+	}
+	return true
+}
+
+// getTransactionsPage query the WalletExtension service
+func (g *GrpcClient) getTransactionsPage(account []byte, direction string, offset, limit int64) (*api.TransactionListExtention, error) {
+	if g.Extension == nil {
+		return nil, ErrWalletExtensionUnavailable
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	request := &api.AccountPaginated{
+		Account: &core.Account{Address: account},
+		Offset:  offset,
+		Limit:   limit,
+	}
+	var list *api.TransactionListExtention
+	var err error
+	if direction == HistoryOutgoing {
+		list, err = g.Extension.GetTransactionsFromThis2(ctx, request)
+	} else {
+		list, err = g.Extension.GetTransactionsToThis2(ctx, request)
+	}
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, fmt.Errorf("%w: %s", ErrWalletExtensionUnavailable, g.Address)
+		}
+		return nil, err
+	}
+	return list, nil
+}
+
+// NewHistoryEntry decode a transaction of the account history
+func NewHistoryEntry(tx *api.TransactionExtention, direction string) (*HistoryEntry, error) {
+	decoded, err := DecodePendingTransaction(tx.GetTransaction())
+	if err != nil {
+		return nil, err
+	}
+	entry := &HistoryEntry{
+		ID:        decoded.ID,
+		Direction: direction,
+		Type:      decoded.Type,
+		Owner:     decoded.Owner,
+		To:        decoded.To,
+		Contract:  decoded.Contract,
+		TokenID:   decoded.TokenID,
+		Amount:    decoded.Amount,
+		Timestamp: historyTimestamp(tx),
+		Raw:       tx,
+	}
+	if len(tx.GetTxid()) > 0 {
+		entry.ID = common.Bytes2Hex(tx.GetTxid())
+	}
+	if results := tx.GetTransaction().GetRet(); len(results) > 0 {
+		entry.Result = results[0].GetContractRet().String()
+	}
+	return entry, nil
+}
@@ -0,0 +1,191 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestAccountHistory_Filters(t *testing.T) {
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	to, _ := tron.Base58ToAddress("TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7")
+	usdt := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+
+	g := client.NewGrpcClient("")
+	_, err := g.AccountHistory(owner.String(), client.HistoryOptions{Direction: "SIDEWAYS"})
+	assert.NotNil(t, err)
+
+	it, err := g.AccountHistory(owner.String(), client.HistoryOptions{
+		Direction:     client.HistoryOutgoing,
+		ContractTypes: []core.Transaction_Contract_ContractType{core.Transaction_Contract_TransferContract},
+	})
+	require.Nil(t, err)
+	assert.False(t, it.Done())
+
+	param, err := anypb.New(&core.TransferContract{OwnerAddress: owner, ToAddress: to, Amount: 5})
+	require.Nil(t, err)
+	tx := &api.TransactionExtention{
+		Txid: []byte{0x0f},
+		Transaction: &core.Transaction{
+			RawData: &core.TransactionRaw{
+				Timestamp: 1600000000000,
+				Contract:  []*core.Transaction_Contract{{Type: core.Transaction_Contract_TransferContract, Parameter: param}},
+			},
+			Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_SUCCESS}},
+		},
+	}
+	entry, err := client.NewHistoryEntry(tx, client.HistoryOutgoing)
+	require.Nil(t, err)
+	assert.Equal(t, "0f", entry.ID)
+	assert.Equal(t, to.String(), entry.To)
+	assert.Equal(t, int64(5), entry.Amount.Int64())
+	assert.Equal(t, "SUCCESS", entry.Result)
+	assert.Equal(t, int64(1600000000), entry.Timestamp.Unix())
+	assert.True(t, it.Match(entry))
+
+	it, err = g.AccountHistory(owner.String(), client.HistoryOptions{TRC20: usdt})
+	require.Nil(t, err)
+	assert.False(t, it.Match(entry))
+	entry.Type = core.Transaction_Contract_TriggerSmartContract.String()
+	entry.Contract = usdt
+	assert.True(t, it.Match(entry))
+
+	// not started: no extension client
+	_, err = it.Next()
+	assert.True(t, errors.Is(err, client.ErrWalletExtensionUnavailable))
+}
+
+// historyNode serves an outgoing history, newest first, and its blocks
+type historyNode struct {
+	api.WalletClient
+	api.WalletExtensionClient
+	txs    []*api.TransactionExtention
+	blocks map[string]int64
+}
+
+// receive prepend a transaction confirmed in block
+func (n *historyNode) receive(id byte, block int64) {
+	tx := &api.TransactionExtention{Txid: []byte{id}, Transaction: &core.Transaction{RawData: &core.TransactionRaw{}}}
+	n.txs = append([]*api.TransactionExtention{tx}, n.txs...)
+	n.blocks[common.Bytes2Hex(tx.Txid)] = block
+}
+
+func (n *historyNode) GetTransactionsFromThis2(_ context.Context, in *api.AccountPaginated, _ ...grpc.CallOption) (*api.TransactionListExtention, error) {
+	list := &api.TransactionListExtention{}
+	for i := in.Offset; i < in.Offset+in.Limit && i < int64(len(n.txs)); i++ {
+		list.Transaction = append(list.Transaction, n.txs[i])
+	}
+	return list, nil
+}
+
+func (n *historyNode) GetTransactionInfoById(_ context.Context, in *api.BytesMessage, _ ...grpc.CallOption) (*core.TransactionInfo, error) {
+	block, ok := n.blocks[common.Bytes2Hex(in.Value)]
+	if !ok {
+		return &core.TransactionInfo{}, nil
+	}
+	return &core.TransactionInfo{Id: in.Value, BlockNumber: block}, nil
+}
+
+func TestAccountHistory_Cursor(t *testing.T) {
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	node := &historyNode{blocks: make(map[string]int64)}
+	for id := byte(1); id <= 5; id++ {
+		node.receive(id, int64(id)*10)
+	}
+	g := client.NewGrpcClient("")
+	g.Client = node
+	g.Extension = node
+
+	ids := func(entries []*client.HistoryEntry) []string {
+		out := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			out = append(out, entry.ID)
+		}
+		return out
+	}
+
+	it, err := g.AccountHistory(owner.String(), client.HistoryOptions{Direction: client.HistoryOutgoing, PageSize: 2})
+	require.Nil(t, err)
+	entries, err := it.Next()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"05", "04"}, ids(entries))
+	cursor := it.Cursor()
+	assert.Equal(t, "04", cursor.Outgoing.TxID)
+	assert.Equal(t, int64(40), cursor.Outgoing.Block)
+
+	// transactions received meanwhile shift the history, resume after 04
+	node.receive(6, 60)
+	node.receive(7, 70)
+	node.receive(8, 80)
+	it, err = g.AccountHistory(owner.String(), client.HistoryOptions{Direction: client.HistoryOutgoing, PageSize: 2, Cursor: cursor})
+	require.Nil(t, err)
+	var read []string
+	for !it.Done() {
+		entries, err := it.Next()
+		require.Nil(t, err)
+		read = append(read, ids(entries)...)
+	}
+	assert.Equal(t, []string{"03", "02", "01"}, read)
+
+	// the last transaction read was dropped, resume by block
+	node.txs = node.txs[:len(node.txs)-1]
+	it, err = g.AccountHistory(owner.String(), client.HistoryOptions{Direction: client.HistoryOutgoing, PageSize: 2, Cursor: client.HistoryCursor{
+		Outgoing: client.HistoryPosition{Block: 35, TxID: "ff", Offset: 1},
+	}})
+	require.Nil(t, err)
+	read = nil
+	for !it.Done() {
+		entries, err := it.Next()
+		require.Nil(t, err)
+		read = append(read, ids(entries)...)
+	}
+	assert.Equal(t, []string{"03", "02"}, read)
+}
+
+func TestAccountHistory_UndecodableEntry(t *testing.T) {
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	node := &historyNode{blocks: make(map[string]int64)}
+	node.receive(1, 10)
+	node.receive(2, 20)
+	node.receive(3, 30)
+	// an unknown contract parameter cannot be decoded
+	node.txs[1].Transaction.RawData.Contract = []*core.Transaction_Contract{{
+		Type:      core.Transaction_Contract_AccountCreateContract,
+		Parameter: &anypb.Any{TypeUrl: "type.googleapis.com/protocol.UnknownContract"},
+	}}
+	g := client.NewGrpcClient("")
+	g.Client = node
+	g.Extension = node
+
+	it, err := g.AccountHistory(owner.String(), client.HistoryOptions{
+		Direction:     client.HistoryOutgoing,
+		ContractTypes: []core.Transaction_Contract_ContractType{core.Transaction_Contract_TransferContract},
+		PageSize:      2,
+	})
+	require.Nil(t, err)
+	entries, err := it.Next()
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "02", entries[0].ID)
+	assert.NotNil(t, entries[0].Err)
+	assert.Equal(t, "02", it.Cursor().Outgoing.TxID)
+
+	// resuming moves past it
+	it, err = g.AccountHistory(owner.String(), client.HistoryOptions{Direction: client.HistoryOutgoing, PageSize: 2, Cursor: it.Cursor()})
+	require.Nil(t, err)
+	entries, err = it.Next()
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "01", entries[0].ID)
+	assert.Nil(t, entries[0].Err)
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+)
+
+// InternalTransfer decoded internal transaction of a contract execution
+type InternalTransfer struct {
+	TxID     string // hex id of the enclosing transaction
+	Hash     string // hex hash of the internal transaction
+	Index    int
+	Caller   string // base58
+	To       string // base58
+	Note     string // call, create, suicide...
+	Amount   int64  // TRX in SUN
+	Tokens   map[string]int64
+	Rejected bool
+}
+
+// DecodeInternalTransaction decode the caller, recipient and values of an
+// internal transaction, TRC10 values are keyed by token id
+func DecodeInternalTransaction(internal *core.InternalTransaction) *InternalTransfer {
+	result := &InternalTransfer{
+		Hash:     hex.EncodeToString(internal.GetHash()),
+		Caller:   tron.Address(internal.GetCallerAddress()).String(),
+		To:       tron.Address(internal.GetTransferToAddress()).String(),
+		Note:     string(internal.GetNote()),
+		Tokens:   make(map[string]int64),
+		Rejected: internal.GetRejected(),
+	}
+	for _, value := range internal.GetCallValueInfo() {
+		if value.GetTokenId() == "" {
+			result.Amount += value.GetCallValue()
+		} else {
+			result.Tokens[value.GetTokenId()] += value.GetCallValue()
+		}
+	}
+	return result
+}
+
+// DecodeInternalTransactions decode every internal transaction of a receipt
+func DecodeInternalTransactions(info *core.TransactionInfo) []*InternalTransfer {
+	txID := hex.EncodeToString(info.GetId())
+	result := make([]*InternalTransfer, 0, len(info.GetInternalTransactions()))
+	for i, internal := range info.GetInternalTransactions() {
+		transfer := DecodeInternalTransaction(internal)
+		transfer.TxID = txID
+		transfer.Index = i
+		result = append(result, transfer)
+	}
+	return result
+}
+
+// GetInternalTransactions returns the internal transactions of a transaction
+func (g *GrpcClient) GetInternalTransactions(id string) ([]*InternalTransfer, error) {
+	info, err := g.GetTransactionInfoByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeInternalTransactions(info), nil
+}
+
+// Deposit TRX or TRC10 received by a watched address
+type Deposit struct {
+	Block    int64
+	TxID     string
+	Index    int // internal transaction index
+	From     string
+	To       string
+	TokenID  string // empty for TRX
+	Amount   int64
+	Internal bool
+}
+
+// DepositScanner finds deposits to watched addresses in blocks
+type DepositScanner struct {
+	client    *GrpcClient
+	addresses map[string]bool
+	// IncludeTRC10 report TRC10 values of internal transactions too
+	IncludeTRC10 bool
+}
+
+// NewDepositScanner watch the base58 addresses
+func NewDepositScanner(g *GrpcClient, addresses ...string) *DepositScanner {
+	s := &DepositScanner{
+		client:    g,
+		addresses: make(map[string]bool),
+	}
+	for _, addr := range addresses {
+		s.addresses[addr] = true
+	}
+	return s
+}
+
+// InternalDeposits returns the internal transfers of a receipt credited to
+// watched addresses, rejected internal transactions and failed executions
+// move nothing and are skipped
+func (s *DepositScanner) InternalDeposits(info *core.TransactionInfo) []*Deposit {
+	deposits := make([]*Deposit, 0)
+	if info.GetResult() == core.TransactionInfo_FAILED {
+		return deposits
+	}
+	for _, transfer := range DecodeInternalTransactions(info) {
+		if transfer.Rejected || !s.addresses[transfer.To] {
+			continue
+		}
+		if transfer.Amount > 0 {
+			deposits = append(deposits, &Deposit{
+				Block:    info.GetBlockNumber(),
+				TxID:     transfer.TxID,
+				Index:    transfer.Index,
+				From:     transfer.Caller,
+				To:       transfer.To,
+				Amount:   transfer.Amount,
+				Internal: true,
+			})
+		}
+		if !s.IncludeTRC10 {
+			continue
+		}
+		tokenIDs := make([]string, 0, len(transfer.Tokens))
+		for tokenID := range transfer.Tokens {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+		sort.Strings(tokenIDs)
+		for _, tokenID := range tokenIDs {
+			amount := transfer.Tokens[tokenID]
+			if amount <= 0 {
+				continue
+			}
+			deposits = append(deposits, &Deposit{
+				Block:    info.GetBlockNumber(),
+				TxID:     transfer.TxID,
+				Index:    transfer.Index,
+				From:     transfer.Caller,
+				To:       transfer.To,
+				TokenID:  tokenID,
+				Amount:   amount,
+				Internal: true,
+			})
+		}
+	}
+	return deposits
+}
+
+// ScanBlock returns the internal deposits of a block
+func (s *DepositScanner) ScanBlock(num int64) ([]*Deposit, error) {
+	infos, err := s.client.GetBlockInfoByNum(num)
+	if err != nil {
+		return nil, fmt.Errorf("block %d: %v", num, err)
+	}
+	deposits := make([]*Deposit, 0)
+	for _, info := range infos.GetTransactionInfo() {
+		deposits = append(deposits, s.InternalDeposits(info)...)
+	}
+	return deposits, nil
+}
@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositScanner_InternalDeposits(t *testing.T) {
+	payout, _ := tron.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	watched, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	other, _ := tron.Base58ToAddress("TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7")
+
+	info := &core.TransactionInfo{
+		Id:          []byte{0xab},
+		BlockNumber: 42,
+		InternalTransactions: []*core.InternalTransaction{
+			{
+				CallerAddress:     payout,
+				TransferToAddress: watched,
+				Note:              []byte("call"),
+				CallValueInfo:     []*core.InternalTransaction_CallValueInfo{{CallValue: 1500}, {CallValue: 7, TokenId: "1002000"}},
+			},
+			{
+				CallerAddress:     payout,
+				TransferToAddress: other,
+				CallValueInfo:     []*core.InternalTransaction_CallValueInfo{{CallValue: 10}},
+			},
+			{
+				CallerAddress:     payout,
+				TransferToAddress: watched,
+				CallValueInfo:     []*core.InternalTransaction_CallValueInfo{{CallValue: 99}},
+				Rejected:          true,
+			},
+		},
+	}
+
+	transfers := client.DecodeInternalTransactions(info)
+	require.Len(t, transfers, 3)
+	assert.Equal(t, "ab", transfers[0].TxID)
+	assert.Equal(t, payout.String(), transfers[0].Caller)
+	assert.Equal(t, watched.String(), transfers[0].To)
+	assert.Equal(t, "call", transfers[0].Note)
+	assert.Equal(t, int64(1500), transfers[0].Amount)
+	assert.Equal(t, map[string]int64{"1002000": 7}, transfers[0].Tokens)
+	assert.True(t, transfers[2].Rejected)
+
+	scanner := client.NewDepositScanner(nil, watched.String())
+	deposits := scanner.InternalDeposits(info)
+	require.Len(t, deposits, 1)
+	assert.Equal(t, &client.Deposit{
+		Block:    42,
+		TxID:     "ab",
+		From:     payout.String(),
+		To:       watched.String(),
+		Amount:   1500,
+		Internal: true,
+	}, deposits[0])
+
+	scanner.IncludeTRC10 = true
+	deposits = scanner.InternalDeposits(info)
+	require.Len(t, deposits, 2)
+	assert.Equal(t, "1002000", deposits[1].TokenID)
+	assert.Equal(t, int64(7), deposits[1].Amount)
+
+	info.Result = core.TransactionInfo_FAILED
+	assert.Empty(t, scanner.InternalDeposits(info))
+}
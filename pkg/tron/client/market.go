@@ -0,0 +1,298 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/common/numeric"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// MarketTRX token id used by the market for TRX
+const MarketTRX = "_"
+
+// MarketPair of TRC10 tokens (or TRX) traded on chain
+type MarketPair struct {
+	SellTokenID string
+	BuyTokenID  string
+}
+
+// MarketPrice level of the order book. Quantities are in base units (sun for
+// TRX), RawPrice is their ratio and Price the amount of buy token asked for one
+// whole sell token, scaled by the precision of both tokens.
+type MarketPrice struct {
+	SellQuantity int64
+	BuyQuantity  int64
+	RawPrice     numeric.Dec
+	Price        numeric.Dec
+}
+
+// MarketOrder on chain order
+type MarketOrder struct {
+	ID           string // hex encoded
+	Owner        string // base58
+	CreateTime   time.Time
+	SellTokenID  string
+	SellQuantity int64
+	BuyTokenID   string
+	BuyQuantity  int64 // minimum to receive
+	SellRemain   int64
+	SellReturn   int64
+	State        string
+	RawPrice     numeric.Dec // buy per sell base unit
+	Price        numeric.Dec // buy per whole sell token
+	Prev         string
+	Next         string
+}
+
+// MarketSellAsset place a sell order of sellQuantity for at least buyQuantity
+func (g *GrpcClient) MarketSellAsset(
+	from string,
+	sellTokenID string,
+	sellQuantity int64,
+	buyTokenID string,
+	buyQuantity int64,
+) (*api.TransactionExtention, error) {
+	var err error
+	if sellQuantity <= 0 || buyQuantity <= 0 {
+		return nil, fmt.Errorf("invalid order quantities %d/%d", sellQuantity, buyQuantity)
+	}
+	if sellTokenID == buyTokenID {
+		return nil, fmt.Errorf("cannot trade %s against itself", sellTokenID)
+	}
+
+	contract := &core.MarketSellAssetContract{
+		SellTokenId:       []byte(sellTokenID),
+		SellTokenQuantity: sellQuantity,
+		BuyTokenId:        []byte(buyTokenID),
+		BuyTokenQuantity:  buyQuantity,
+	}
+	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	tx, err := g.Client.MarketSellAsset(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	return tx, nil
+}
+
+// MarketCancelOrder cancel an order by its hex id
+func (g *GrpcClient) MarketCancelOrder(from, orderID string) (*api.TransactionExtention, error) {
+	var err error
+	contract := &core.MarketCancelOrderContract{}
+	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
+		return nil, err
+	}
+	if contract.OrderId, err = common.FromHex(orderID); err != nil {
+		return nil, fmt.Errorf("invalid order id %s: %v", orderID, err)
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	tx, err := g.Client.MarketCancelOrder(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	return tx, nil
+}
+
+// MarketOrderByID returns an order by its hex id
+func (g *GrpcClient) MarketOrderByID(orderID string) (*MarketOrder, error) {
+	id, err := common.FromHex(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order id %s: %v", orderID, err)
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	order, err := g.Client.GetMarketOrderById(ctx, GetMessageBytes(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(order.GetOrderId()) == 0 {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+	return g.newMarketOrder(order)
+}
+
+// MarketOpenOrders returns the active orders of an account
+func (g *GrpcClient) MarketOpenOrders(addr string) ([]*MarketOrder, error) {
+	account, err := common.DecodeCheck(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	list, err := g.Client.GetMarketOrderByAccount(ctx, GetMessageBytes(account))
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]*MarketOrder, 0, len(list.GetOrders()))
+	for _, order := range list.GetOrders() {
+		if order.GetState() != core.MarketOrder_ACTIVE {
+			continue
+		}
+		marketOrder, err := g.newMarketOrder(order)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, marketOrder)
+	}
+	return orders, nil
+}
+
+// MarketPairs returns every pair with open orders
+func (g *GrpcClient) MarketPairs() ([]MarketPair, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	list, err := g.Client.GetMarketPairList(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]MarketPair, 0, len(list.GetOrderPair()))
+	for _, pair := range list.GetOrderPair() {
+		pairs = append(pairs, MarketPair{
+			SellTokenID: string(pair.GetSellTokenId()),
+			BuyTokenID:  string(pair.GetBuyTokenId()),
+		})
+	}
+	return pairs, nil
+}
+
+// MarketPrices returns the price levels of a pair, best price first
+func (g *GrpcClient) MarketPrices(sellTokenID, buyTokenID string) ([]MarketPrice, error) {
+	sellPrecision, err := g.MarketPrecision(sellTokenID)
+	if err != nil {
+		return nil, err
+	}
+	buyPrecision, err := g.MarketPrecision(buyTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	list, err := g.Client.GetMarketPriceByPair(ctx, &core.MarketOrderPair{
+		SellTokenId: []byte(sellTokenID),
+		BuyTokenId:  []byte(buyTokenID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	prices := make([]MarketPrice, 0, len(list.GetPrices()))
+	for _, price := range list.GetPrices() {
+		prices = append(prices, MarketPrice{
+			SellQuantity: price.GetSellTokenQuantity(),
+			BuyQuantity:  price.GetBuyTokenQuantity(),
+			RawPrice:     MarketPriceOf(price.GetSellTokenQuantity(), price.GetBuyTokenQuantity(), 0, 0),
+			Price:        MarketPriceOf(price.GetSellTokenQuantity(), price.GetBuyTokenQuantity(), sellPrecision, buyPrecision),
+		})
+	}
+	return prices, nil
+}
+
+// MarketOrderBook returns the open orders of a pair
+func (g *GrpcClient) MarketOrderBook(sellTokenID, buyTokenID string) ([]*MarketOrder, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	list, err := g.Client.GetMarketOrderListByPair(ctx, &core.MarketOrderPair{
+		SellTokenId: []byte(sellTokenID),
+		BuyTokenId:  []byte(buyTokenID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]*MarketOrder, 0, len(list.GetOrders()))
+	for _, order := range list.GetOrders() {
+		marketOrder, err := g.newMarketOrder(order)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, marketOrder)
+	}
+	return orders, nil
+}
+
+// MarketPrecision returns the decimals of a market token, 6 for TRX
+func (g *GrpcClient) MarketPrecision(tokenID string) (int32, error) {
+	if tokenID == MarketTRX {
+		return common.AmountDecimalPoint, nil
+	}
+	metadata, err := g.GetTRC10Metadata(tokenID)
+	if err != nil {
+		return 0, err
+	}
+	return metadata.Precision, nil
+}
+
+func (g *GrpcClient) newMarketOrder(order *core.MarketOrder) (*MarketOrder, error) {
+	sellPrecision, err := g.MarketPrecision(string(order.GetSellTokenId()))
+	if err != nil {
+		return nil, err
+	}
+	buyPrecision, err := g.MarketPrecision(string(order.GetBuyTokenId()))
+	if err != nil {
+		return nil, err
+	}
+	return NewMarketOrder(order, sellPrecision, buyPrecision), nil
+}
+
+// NewMarketOrder convert a core market order, sellPrecision and buyPrecision
+// being the decimals of its tokens (see MarketPrecision)
+func NewMarketOrder(order *core.MarketOrder, sellPrecision, buyPrecision int32) *MarketOrder {
+	return &MarketOrder{
+		ID:           hex.EncodeToString(order.GetOrderId()),
+		Owner:        tron.Address(order.GetOwnerAddress()).String(),
+		CreateTime:   time.Unix(0, order.GetCreateTime()*int64(time.Millisecond)),
+		SellTokenID:  string(order.GetSellTokenId()),
+		SellQuantity: order.GetSellTokenQuantity(),
+		BuyTokenID:   string(order.GetBuyTokenId()),
+		BuyQuantity:  order.GetBuyTokenQuantity(),
+		SellRemain:   order.GetSellTokenQuantityRemain(),
+		SellReturn:   order.GetSellTokenQuantityReturn(),
+		State:        order.GetState().String(),
+		RawPrice:     MarketPriceOf(order.GetSellTokenQuantity(), order.GetBuyTokenQuantity(), 0, 0),
+		Price:        MarketPriceOf(order.GetSellTokenQuantity(), order.GetBuyTokenQuantity(), sellPrecision, buyPrecision),
+		Prev:         hex.EncodeToString(order.GetPrev()),
+		Next:         hex.EncodeToString(order.GetNext()),
+	}
+}
+
+// MarketPriceOf returns the buy amount per whole sell token of base unit
+// quantities, zero when sell quantity is zero. With both precisions 0 it is the
+// raw ratio of the quantities.
+func MarketPriceOf(sellQuantity, buyQuantity int64, sellPrecision, buyPrecision int32) numeric.Dec {
+	if sellQuantity == 0 {
+		return numeric.ZeroDec()
+	}
+	return TRC10ToAmount(buyQuantity, buyPrecision).Quo(TRC10ToAmount(sellQuantity, sellPrecision))
+}
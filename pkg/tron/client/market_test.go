@@ -0,0 +1,36 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMarketOrder(t *testing.T) {
+	owner := []byte{0x41, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10, 0x11, 0x12, 0x13, 0x14}
+	order := client.NewMarketOrder(&core.MarketOrder{
+		OrderId:                 []byte{0xab, 0xcd},
+		OwnerAddress:            owner,
+		CreateTime:              1600000000123,
+		SellTokenId:             []byte("1000001"),
+		SellTokenQuantity:       400,
+		BuyTokenId:              []byte(client.MarketTRX),
+		BuyTokenQuantity:        100,
+		SellTokenQuantityRemain: 300,
+		State:                   core.MarketOrder_ACTIVE,
+	}, 0, 6)
+	assert.Equal(t, "abcd", order.ID)
+	assert.Equal(t, tron.Address(owner).String(), order.Owner)
+	assert.Equal(t, int64(1600000000123), order.CreateTime.UnixNano()/1e6)
+	assert.Equal(t, "1000001", order.SellTokenID)
+	assert.Equal(t, "ACTIVE", order.State)
+	assert.Equal(t, "0.250000000000000000", order.RawPrice.String())
+	// 400 whole tokens of precision 0 for 100 sun
+	assert.Equal(t, "0.000000250000000000", order.Price.String())
+	assert.True(t, client.MarketPriceOf(0, 10, 6, 6).IsZero())
+	// 2 TRX for 1.5 token of precision 2
+	assert.Equal(t, "0.750000000000000000", client.MarketPriceOf(2000000, 150, 6, 2).String())
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/EntySquare/chain-util/pkg/tron/common"
 	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
@@ -11,6 +12,9 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrTransactionInfoNotFound is returned by GetTransactionInfoByID for transactions not in a block
+var ErrTransactionInfoNotFound = errors.New("transaction info not found")
+
 // ListNodes provides list of network nodes
 func (g *GrpcClient) ListNodes() (*api.NodeList, error) {
 	ctx, cancel := g.getContext()
@@ -84,7 +88,7 @@ func (g *GrpcClient) GetTransactionInfoByID(id string) (*core.TransactionInfo, e
 	if bytes.Equal(txi.Id, transactionID.Value) {
 		return txi, nil
 	}
-	return nil, fmt.Errorf("transaction info not found")
+	return nil, ErrTransactionInfoNotFound
 }
 
 // Broadcast broadcast TX
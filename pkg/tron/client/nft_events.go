@@ -0,0 +1,258 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	eCommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	trc721TransferEvent = &core.SmartContract_ABI_Entry{
+		Name: "Transfer",
+		Type: core.SmartContract_ABI_Entry_Event,
+		Inputs: []*core.SmartContract_ABI_Entry_Param{
+			{Indexed: true, Name: "from", Type: "address"},
+			{Indexed: true, Name: "to", Type: "address"},
+			{Indexed: true, Name: "tokenId", Type: "uint256"},
+		},
+	}
+	trc1155TransferSingleEvent = &core.SmartContract_ABI_Entry{
+		Name: "TransferSingle",
+		Type: core.SmartContract_ABI_Entry_Event,
+		Inputs: []*core.SmartContract_ABI_Entry_Param{
+			{Indexed: true, Name: "operator", Type: "address"},
+			{Indexed: true, Name: "from", Type: "address"},
+			{Indexed: true, Name: "to", Type: "address"},
+			{Name: "id", Type: "uint256"},
+			{Name: "value", Type: "uint256"},
+		},
+	}
+	trc1155TransferBatchEvent = &core.SmartContract_ABI_Entry{
+		Name: "TransferBatch",
+		Type: core.SmartContract_ABI_Entry_Event,
+		Inputs: []*core.SmartContract_ABI_Entry_Param{
+			{Indexed: true, Name: "operator", Type: "address"},
+			{Indexed: true, Name: "from", Type: "address"},
+			{Indexed: true, Name: "to", Type: "address"},
+			{Name: "ids", Type: "uint256[]"},
+			{Name: "values", Type: "uint256[]"},
+		},
+	}
+
+	trc721TransferTopic        = abi.EventTopic("Transfer(address,address,uint256)")
+	trc1155TransferSingleTopic = abi.EventTopic("TransferSingle(address,address,address,uint256,uint256)")
+	trc1155TransferBatchTopic  = abi.EventTopic("TransferBatch(address,address,address,uint256[],uint256[])")
+)
+
+// NFT standards
+const (
+	StandardTRC721  = "TRC721"
+	StandardTRC1155 = "TRC1155"
+)
+
+// TRC721Transfer decoded TRC721 Transfer event
+type TRC721Transfer struct {
+	Contract string
+	From     string
+	To       string
+	TokenID  *big.Int
+}
+
+// TRC1155TransferSingle decoded TRC1155 TransferSingle event
+type TRC1155TransferSingle struct {
+	Contract string
+	Operator string
+	From     string
+	To       string
+	ID       *big.Int
+	Value    *big.Int
+}
+
+// TRC1155TransferBatch decoded TRC1155 TransferBatch event
+type TRC1155TransferBatch struct {
+	Contract string
+	Operator string
+	From     string
+	To       string
+	IDs      []*big.Int
+	Values   []*big.Int
+}
+
+// NFTTransfer single token movement, batch transfers are split per id.
+// From is the zero address on mint and To on burn
+type NFTTransfer struct {
+	Standard string
+	Contract string
+	Operator string
+	From     string
+	To       string
+	TokenID  *big.Int
+	Amount   *big.Int
+	LogIndex int
+}
+
+// DecodeTRC721Transfer decode a TRC721 Transfer log, TRC20 transfers share the
+// same signature but do not index the amount and are rejected
+func DecodeTRC721Transfer(log *core.TransactionInfo_Log) (*TRC721Transfer, error) {
+	values, err := abi.UnpackLog(trc721TransferEvent, log)
+	if err != nil {
+		return nil, err
+	}
+	return &TRC721Transfer{
+		Contract: logContract(log),
+		From:     values["from"].(string),
+		To:       values["to"].(string),
+		TokenID:  values["tokenId"].(*big.Int),
+	}, nil
+}
+
+// DecodeTRC1155TransferSingle decode a TRC1155 TransferSingle log
+func DecodeTRC1155TransferSingle(log *core.TransactionInfo_Log) (*TRC1155TransferSingle, error) {
+	values, err := abi.UnpackLog(trc1155TransferSingleEvent, log)
+	if err != nil {
+		return nil, err
+	}
+	return &TRC1155TransferSingle{
+		Contract: logContract(log),
+		Operator: values["operator"].(string),
+		From:     values["from"].(string),
+		To:       values["to"].(string),
+		ID:       values["id"].(*big.Int),
+		Value:    values["value"].(*big.Int),
+	}, nil
+}
+
+// DecodeTRC1155TransferBatch decode a TRC1155 TransferBatch log
+func DecodeTRC1155TransferBatch(log *core.TransactionInfo_Log) (*TRC1155TransferBatch, error) {
+	values, err := abi.UnpackLog(trc1155TransferBatchEvent, log)
+	if err != nil {
+		return nil, err
+	}
+	event := &TRC1155TransferBatch{
+		Contract: logContract(log),
+		Operator: values["operator"].(string),
+		From:     values["from"].(string),
+		To:       values["to"].(string),
+		IDs:      values["ids"].([]*big.Int),
+		Values:   values["values"].([]*big.Int),
+	}
+	if len(event.IDs) != len(event.Values) {
+		return nil, fmt.Errorf("invalid TransferBatch: ids and values length mismatch %d/%d", len(event.IDs), len(event.Values))
+	}
+	return event, nil
+}
+
+// NFTLogError is a log matching an NFT transfer topic that could not be decoded
+type NFTLogError struct {
+	LogIndex int
+	Err      error
+}
+
+func (e *NFTLogError) Error() string {
+	return fmt.Sprintf("log %d: %v", e.LogIndex, e.Err)
+}
+
+func (e *NFTLogError) Unwrap() error {
+	return e.Err
+}
+
+// NFTLogErrors lists the undecodable logs of a transaction receipt
+type NFTLogErrors []*NFTLogError
+
+func (e NFTLogErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// DecodeNFTTransfers return every TRC721 and TRC1155 token movement of a transaction receipt.
+// A log that matches a transfer topic but cannot be decoded does not stop the others,
+// the transfers are returned along with NFTLogErrors listing the failed logs.
+func DecodeNFTTransfers(info *core.TransactionInfo) ([]*NFTTransfer, error) {
+	transfers := make([]*NFTTransfer, 0)
+	var failed NFTLogErrors
+	for i, log := range info.GetLog() {
+		decoded, err := decodeNFTLog(i, log)
+		if err != nil {
+			failed = append(failed, &NFTLogError{LogIndex: i, Err: err})
+			continue
+		}
+		transfers = append(transfers, decoded...)
+	}
+	if len(failed) > 0 {
+		return transfers, failed
+	}
+	return transfers, nil
+}
+
+// decodeNFTLog return the transfers of the log at index i, none when it is not an NFT transfer
+func decodeNFTLog(i int, log *core.TransactionInfo_Log) ([]*NFTTransfer, error) {
+	if len(log.GetTopics()) == 0 {
+		return nil, nil
+	}
+	topic := log.GetTopics()[0]
+	switch {
+	case bytes.Equal(topic, trc721TransferTopic) && len(log.GetTopics()) == 4:
+		event, err := DecodeTRC721Transfer(log)
+		if err != nil {
+			return nil, err
+		}
+		return []*NFTTransfer{{
+			Standard: StandardTRC721,
+			Contract: event.Contract,
+			From:     event.From,
+			To:       event.To,
+			TokenID:  event.TokenID,
+			Amount:   big.NewInt(1),
+			LogIndex: i,
+		}}, nil
+	case bytes.Equal(topic, trc1155TransferSingleTopic):
+		event, err := DecodeTRC1155TransferSingle(log)
+		if err != nil {
+			return nil, err
+		}
+		return []*NFTTransfer{{
+			Standard: StandardTRC1155,
+			Contract: event.Contract,
+			Operator: event.Operator,
+			From:     event.From,
+			To:       event.To,
+			TokenID:  event.ID,
+			Amount:   event.Value,
+			LogIndex: i,
+		}}, nil
+	case bytes.Equal(topic, trc1155TransferBatchTopic):
+		event, err := DecodeTRC1155TransferBatch(log)
+		if err != nil {
+			return nil, err
+		}
+		transfers := make([]*NFTTransfer, len(event.IDs))
+		for j := range event.IDs {
+			transfers[j] = &NFTTransfer{
+				Standard: StandardTRC1155,
+				Contract: event.Contract,
+				Operator: event.Operator,
+				From:     event.From,
+				To:       event.To,
+				TokenID:  event.IDs[j],
+				Amount:   event.Values[j],
+				LogIndex: i,
+			}
+		}
+		return transfers, nil
+	}
+	return nil, nil
+}
+
+// logContract returns the base58 address of the contract emitting log
+func logContract(log *core.TransactionInfo_Log) string {
+	return tron.AddressToTronAddress(eCommon.BytesToAddress(log.GetAddress())).String()
+}
@@ -0,0 +1,96 @@
+package client_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeNFTTransfers(t *testing.T) {
+	contract := eCommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+	operator := eCommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	from := eCommon.HexToAddress("0x2222222222222222222222222222222222222222")
+	to := eCommon.HexToAddress("0x3333333333333333333333333333333333333333")
+	topic := func(a eCommon.Address) []byte { return eCommon.BytesToHash(a.Bytes()).Bytes() }
+	word := func(i int64) []byte { return eCommon.BigToHash(big.NewInt(i)).Bytes() }
+
+	trc20 := &core.TransactionInfo_Log{
+		Address: contract.Bytes(),
+		Topics:  [][]byte{abi.EventTopic("Transfer(address,address,uint256)"), topic(from), topic(to)},
+		Data:    word(100),
+	}
+	trc721 := &core.TransactionInfo_Log{
+		Address: contract.Bytes(),
+		Topics:  [][]byte{abi.EventTopic("Transfer(address,address,uint256)"), topic(from), topic(to), word(7)},
+	}
+	single := &core.TransactionInfo_Log{
+		Address: contract.Bytes(),
+		Topics:  [][]byte{abi.EventTopic("TransferSingle(address,address,address,uint256,uint256)"), topic(operator), topic(from), topic(to)},
+		Data:    append(word(5), word(10)...),
+	}
+	// ids [1,2] values [3,4]
+	batchData := append(word(64), word(160)...)
+	batchData = append(batchData, word(2)...)
+	batchData = append(batchData, word(1)...)
+	batchData = append(batchData, word(2)...)
+	batchData = append(batchData, word(2)...)
+	batchData = append(batchData, word(3)...)
+	batchData = append(batchData, word(4)...)
+	batch := &core.TransactionInfo_Log{
+		Address: contract.Bytes(),
+		Topics:  [][]byte{abi.EventTopic("TransferBatch(address,address,address,uint256[],uint256[])"), topic(operator), topic(from), topic(to)},
+		Data:    batchData,
+	}
+
+	transfers, err := client.DecodeNFTTransfers(&core.TransactionInfo{
+		Log: []*core.TransactionInfo_Log{trc20, trc721, single, batch},
+	})
+	require.Nil(t, err)
+	require.Len(t, transfers, 4)
+
+	contractAddress := tron.AddressToTronAddress(contract).String()
+	assert.Equal(t, client.StandardTRC721, transfers[0].Standard)
+	assert.Equal(t, contractAddress, transfers[0].Contract)
+	assert.Equal(t, tron.AddressToTronAddress(from).String(), transfers[0].From)
+	assert.Equal(t, tron.AddressToTronAddress(to).String(), transfers[0].To)
+	assert.Equal(t, int64(7), transfers[0].TokenID.Int64())
+	assert.Equal(t, 1, transfers[0].LogIndex)
+
+	assert.Equal(t, client.StandardTRC1155, transfers[1].Standard)
+	assert.Equal(t, tron.AddressToTronAddress(operator).String(), transfers[1].Operator)
+	assert.Equal(t, int64(5), transfers[1].TokenID.Int64())
+	assert.Equal(t, int64(10), transfers[1].Amount.Int64())
+
+	assert.Equal(t, int64(1), transfers[2].TokenID.Int64())
+	assert.Equal(t, int64(3), transfers[2].Amount.Int64())
+	assert.Equal(t, int64(2), transfers[3].TokenID.Int64())
+	assert.Equal(t, int64(4), transfers[3].Amount.Int64())
+
+	_, err = client.DecodeTRC721Transfer(trc20)
+	assert.NotNil(t, err)
+
+	// an undecodable log is reported without dropping the others
+	truncated := &core.TransactionInfo_Log{
+		Address: contract.Bytes(),
+		Topics:  single.Topics,
+		Data:    word(5),
+	}
+	transfers, err = client.DecodeNFTTransfers(&core.TransactionInfo{
+		Log: []*core.TransactionInfo_Log{trc721, truncated, single},
+	})
+	require.Len(t, transfers, 2)
+	assert.Equal(t, 0, transfers[0].LogIndex)
+	assert.Equal(t, 2, transfers[1].LogIndex)
+	var failed client.NFTLogErrors
+	require.True(t, errors.As(err, &failed))
+	require.Len(t, failed, 1)
+	assert.Equal(t, 1, failed[0].LogIndex)
+}
@@ -0,0 +1,329 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// Transaction states reported by TransactionStatus
+const (
+	TransactionPending   = "PENDING"
+	TransactionConfirmed = "CONFIRMED"
+	// TransactionUnknown is neither pending nor in a block: not yet
+	// propagated to this node or dropped from the pool
+	TransactionUnknown = "UNKNOWN"
+)
+
+// ErrPendingTransactionNotFound is returned by GetPendingTransaction for transactions not in the pool
+var ErrPendingTransactionNotFound = errors.New("pending transaction not found")
+
+var trc20TransferSelector, _ = common.FromHex(trc20TransferMethodSignature)
+
+// PendingTransaction decoded transaction of the pending pool
+type PendingTransaction struct {
+	ID       string
+	Type     string
+	Owner    string // base58
+	To       string // base58 recipient of TRX, TRC10 or TRC20 transfers
+	Contract string // base58 called contract
+	TokenID  string // TRC10 token id
+	Amount   *big.Int
+	Raw      *core.Transaction
+}
+
+// GetPendingSize returns the number of transactions in the node pending pool
+func (g *GrpcClient) GetPendingSize() (int64, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	result, err := g.Client.GetPendingSize(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return 0, err
+	}
+	return result.GetNum(), nil
+}
+
+// GetPendingTransactionIDs list the IDs of the pending pool
+func (g *GrpcClient) GetPendingTransactionIDs() ([]string, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	result, err := g.Client.GetTransactionListFromPending(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, err
+	}
+	return result.GetTxId(), nil
+}
+
+// GetPendingTransaction returns a transaction of the pending pool
+func (g *GrpcClient) GetPendingTransaction(id string) (*core.Transaction, error) {
+	transactionID := new(api.BytesMessage)
+	var err error
+
+	transactionID.Value, err = common.FromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("get pending transaction error: %v", err)
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	tx, err := g.Client.GetTransactionFromPending(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if size := proto.Size(tx); size > 0 {
+		return tx, nil
+	}
+	return nil, ErrPendingTransactionNotFound
+}
+
+// GetPendingTransactionDecoded returns a decoded transaction of the pending pool
+func (g *GrpcClient) GetPendingTransactionDecoded(id string) (*PendingTransaction, error) {
+	tx, err := g.GetPendingTransaction(id)
+	if err != nil {
+		return nil, err
+	}
+	return DecodePendingTransaction(tx)
+}
+
+// TransactionStatus tells whether a broadcast transaction is pending, in a block
+// or unknown to the node (not yet propagated or dropped)
+func (g *GrpcClient) TransactionStatus(id string) (string, error) {
+	if _, err := common.FromHex(id); err != nil {
+		return "", fmt.Errorf("invalid transaction id %s: %v", id, err)
+	}
+	_, err := g.GetTransactionInfoByID(id)
+	if err == nil {
+		return TransactionConfirmed, nil
+	}
+	if !errors.Is(err, ErrTransactionInfoNotFound) {
+		return "", err
+	}
+	_, err = g.GetPendingTransaction(id)
+	if err == nil {
+		return TransactionPending, nil
+	}
+	if !errors.Is(err, ErrPendingTransactionNotFound) {
+		return "", err
+	}
+	return TransactionUnknown, nil
+}
+
+// DecodePendingTransaction extract the owner, recipient and amount of TRX,
+// TRC10 and TRC20 transfers, other contracts only carry owner and type
+func DecodePendingTransaction(tx *core.Transaction) (*PendingTransaction, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(rawData)
+	result := &PendingTransaction{
+		ID:  hex.EncodeToString(hash[:]),
+		Raw: tx,
+	}
+	if len(tx.GetRawData().GetContract()) == 0 {
+		return result, nil
+	}
+
+	contract := tx.GetRawData().GetContract()[0]
+	result.Type = contract.GetType().String()
+	switch contract.GetType() {
+	case core.Transaction_Contract_TransferContract:
+		c := &core.TransferContract{}
+		if err := contract.GetParameter().UnmarshalTo(c); err != nil {
+			return nil, err
+		}
+		result.Owner = tron.Address(c.GetOwnerAddress()).String()
+		result.To = tron.Address(c.GetToAddress()).String()
+		result.Amount = big.NewInt(c.GetAmount())
+	case core.Transaction_Contract_TransferAssetContract:
+		c := &core.TransferAssetContract{}
+		if err := contract.GetParameter().UnmarshalTo(c); err != nil {
+			return nil, err
+		}
+		result.Owner = tron.Address(c.GetOwnerAddress()).String()
+		result.To = tron.Address(c.GetToAddress()).String()
+		result.TokenID = string(c.GetAssetName())
+		result.Amount = big.NewInt(c.GetAmount())
+	case core.Transaction_Contract_TriggerSmartContract:
+		c := &core.TriggerSmartContract{}
+		if err := contract.GetParameter().UnmarshalTo(c); err != nil {
+			return nil, err
+		}
+		result.Owner = tron.Address(c.GetOwnerAddress()).String()
+		result.Contract = tron.Address(c.GetContractAddress()).String()
+		data := c.GetData()
+		// transfer(address,uint256)
+		if len(data) == 4+64 && bytes.Equal(data[:4], trc20TransferSelector) {
+			result.To = tron.AddressToTronAddress(eCommon.BytesToAddress(data[4:36])).String()
+			result.Amount = new(big.Int).SetBytes(data[36:68])
+		}
+	default:
+		owner, err := contractOwner(contract)
+		if err != nil {
+			return nil, err
+		}
+		result.Owner = owner
+	}
+	return result, nil
+}
+
+// contractOwner read owner_address, present on every contract type
+func contractOwner(contract *core.Transaction_Contract) (string, error) {
+	message, err := contract.GetParameter().UnmarshalNew()
+	if err != nil {
+		return "", err
+	}
+	field := message.ProtoReflect().Descriptor().Fields().ByName("owner_address")
+	if field == nil {
+		return "", nil
+	}
+	return tron.Address(message.ProtoReflect().Get(field).Bytes()).String(), nil
+}
+
+// PendingWatcher reports pending transactions touching watched addresses
+type PendingWatcher struct {
+	client    *GrpcClient
+	mu        sync.Mutex
+	addresses map[string]bool
+	seen      map[string]bool
+	onError   func(id string, err error)
+}
+
+// NewPendingWatcher watch the pending pool for the base58 addresses
+func NewPendingWatcher(g *GrpcClient, addresses ...string) *PendingWatcher {
+	w := &PendingWatcher{
+		client:    g,
+		addresses: make(map[string]bool),
+		seen:      make(map[string]bool),
+	}
+	w.Add(addresses...)
+	return w
+}
+
+// Add addresses to the watch list
+func (w *PendingWatcher) Add(addresses ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, addr := range addresses {
+		w.addresses[addr] = true
+	}
+}
+
+// Remove addresses from the watch list
+func (w *PendingWatcher) Remove(addresses ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, addr := range addresses {
+		delete(w.addresses, addr)
+	}
+}
+
+// OnDecodeError sets the handler of pending transactions that cannot be
+// decoded, they are reported once and skipped by later polls
+func (w *PendingWatcher) OnDecodeError(handler func(id string, err error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onError = handler
+}
+
+// Matches returns true when tx involves a watched address
+func (w *PendingWatcher) Matches(tx *PendingTransaction) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addresses[tx.Owner] || w.addresses[tx.To] || w.addresses[tx.Contract]
+}
+
+// Poll returns the pending transactions touching watched addresses that
+// were not reported by a previous poll, it must not be called concurrently.
+// On a node error nothing is marked as reported and the next poll retries,
+// transactions that cannot be decoded go to the OnDecodeError handler.
+func (w *PendingWatcher) Poll() ([]*PendingTransaction, error) {
+	ids, err := w.client.GetPendingTransactionIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]bool, len(ids))
+	fetched := make([]string, 0)
+	failed := make(map[string]error)
+	result := make([]*PendingTransaction, 0)
+	for _, id := range ids {
+		current[id] = true
+		if w.seen[id] {
+			continue
+		}
+		raw, err := w.client.GetPendingTransaction(id)
+		if errors.Is(err, ErrPendingTransactionNotFound) {
+			// included in a block or dropped since listed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pending transaction %s: %w", id, err)
+		}
+		fetched = append(fetched, id)
+		tx, err := DecodePendingTransaction(raw)
+		if err != nil {
+			failed[id] = err
+			continue
+		}
+		if w.Matches(tx) {
+			result = append(result, tx)
+		}
+	}
+	for _, id := range fetched {
+		w.seen[id] = true
+	}
+	w.mu.Lock()
+	onError := w.onError
+	w.mu.Unlock()
+	if onError != nil {
+		for _, id := range ids {
+			if err, ok := failed[id]; ok {
+				onError(id, err)
+			}
+		}
+	}
+	// forget transactions that left the pool
+	for id := range w.seen {
+		if !current[id] {
+			delete(w.seen, id)
+		}
+	}
+	return result, nil
+}
+
+// Watch poll the pending pool every interval until ctx is done
+func (w *PendingWatcher) Watch(ctx context.Context, interval time.Duration, handler func(*PendingTransaction)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		txs, err := w.Poll()
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			handler(tx)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package client_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func pendingTx(t *testing.T, contractType core.Transaction_Contract_ContractType, param *anypb.Any) *core.Transaction {
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{Type: contractType, Parameter: param}},
+	}}
+}
+
+func TestDecodePendingTransaction(t *testing.T) {
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	to, _ := tron.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	param, err := anypb.New(&core.TransferContract{OwnerAddress: owner, ToAddress: to, Amount: 1000})
+	require.Nil(t, err)
+	tx, err := client.DecodePendingTransaction(pendingTx(t, core.Transaction_Contract_TransferContract, param))
+	require.Nil(t, err)
+	assert.Len(t, tx.ID, 64)
+	assert.Equal(t, "TransferContract", tx.Type)
+	assert.Equal(t, owner.String(), tx.Owner)
+	assert.Equal(t, to.String(), tx.To)
+	assert.Equal(t, int64(1000), tx.Amount.Int64())
+
+	data, err := abi.Pack("transfer(address,uint256)", []abi.Param{
+		{"address": owner.String()},
+		{"uint256": big.NewInt(5)},
+	})
+	require.Nil(t, err)
+	param, err = anypb.New(&core.TriggerSmartContract{OwnerAddress: owner, ContractAddress: to, Data: data})
+	require.Nil(t, err)
+	tx, err = client.DecodePendingTransaction(pendingTx(t, core.Transaction_Contract_TriggerSmartContract, param))
+	require.Nil(t, err)
+	assert.Equal(t, to.String(), tx.Contract)
+	assert.Equal(t, owner.String(), tx.To)
+	assert.Equal(t, int64(5), tx.Amount.Int64())
+
+	param, err = anypb.New(&core.FreezeBalanceV2Contract{OwnerAddress: owner, FrozenBalance: 1})
+	require.Nil(t, err)
+	tx, err = client.DecodePendingTransaction(pendingTx(t, core.Transaction_Contract_FreezeBalanceV2Contract, param))
+	require.Nil(t, err)
+	assert.Equal(t, owner.String(), tx.Owner)
+	assert.Empty(t, tx.To)
+}
+
+// pendingNode serves a pending pool, unavailable makes the lookups fail
+type pendingNode struct {
+	api.WalletClient
+	pool        map[string]*core.Transaction
+	ids         []string
+	unavailable bool
+}
+
+func (n *pendingNode) GetTransactionListFromPending(context.Context, *api.EmptyMessage, ...grpc.CallOption) (*api.TransactionIdList, error) {
+	return &api.TransactionIdList{TxId: n.ids}, nil
+}
+
+func (n *pendingNode) GetTransactionFromPending(_ context.Context, id *api.BytesMessage, _ ...grpc.CallOption) (*core.Transaction, error) {
+	if n.unavailable {
+		return nil, errors.New("unavailable")
+	}
+	if tx, ok := n.pool[hex.EncodeToString(id.GetValue())]; ok {
+		return tx, nil
+	}
+	return &core.Transaction{}, nil
+}
+
+func TestPendingWatcher_Poll(t *testing.T) {
+	owner, _ := tron.Base58ToAddress("TYVrnhrwqxJMURy4WiSpykdgioCsEFLJDf")
+	to, _ := tron.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	param, err := anypb.New(&core.TransferContract{OwnerAddress: owner, ToAddress: to, Amount: 1000})
+	require.Nil(t, err)
+	odd := &anypb.Any{TypeUrl: "type.googleapis.com/protocol.UnknownContract"}
+
+	node := &pendingNode{
+		ids: []string{"01", "02"},
+		pool: map[string]*core.Transaction{
+			"01": pendingTx(t, core.Transaction_Contract_AccountCreateContract, odd),
+			"02": pendingTx(t, core.Transaction_Contract_TransferContract, param),
+		},
+	}
+	g := client.NewGrpcClient("")
+	g.Client = node
+	watcher := client.NewPendingWatcher(g, to.String())
+	failed := make([]string, 0)
+	watcher.OnDecodeError(func(id string, err error) {
+		assert.NotNil(t, err)
+		failed = append(failed, id)
+	})
+
+	// a node error aborts the poll and nothing is reported
+	node.unavailable = true
+	_, err = watcher.Poll()
+	assert.NotNil(t, err)
+	node.unavailable = false
+
+	// an undecodable transaction does not stop the others
+	txs, err := watcher.Poll()
+	require.Nil(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, to.String(), txs[0].To)
+	assert.Equal(t, []string{"01"}, failed)
+
+	txs, err = watcher.Poll()
+	require.Nil(t, err)
+	assert.Empty(t, txs)
+	assert.Equal(t, []string{"01"}, failed)
+}
@@ -72,7 +72,13 @@ func (g *GrpcClient) ProposalApprove(from string, id int64, confirm bool) (*api.
 	return tx, nil
 }
 
+// ProposalWithdraw cancel a pending proposal of from
 func (g *GrpcClient) ProposalWithdraw(from string, id int64) (*api.TransactionExtention, error) {
+	return g.ProposalDelete(from, id)
+}
+
+// ProposalDelete cancel a pending proposal, only the proposer can delete it
+func (g *GrpcClient) ProposalDelete(from string, id int64) (*api.TransactionExtention, error) {
 	var err error
 
 	contract := &core.ProposalDeleteContract{
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChainParameter id of a network parameter changed by proposals
+type ChainParameter int64
+
+// Known chain parameters
+const (
+	ParamMaintenanceTimeInterval              ChainParameter = 0
+	ParamAccountUpgradeCost                   ChainParameter = 1
+	ParamCreateAccountFee                     ChainParameter = 2
+	ParamTransactionFee                       ChainParameter = 3
+	ParamAssetIssueFee                        ChainParameter = 4
+	ParamWitnessPayPerBlock                   ChainParameter = 5
+	ParamWitnessStandbyAllowance              ChainParameter = 6
+	ParamCreateNewAccountFeeInSystemContract  ChainParameter = 7
+	ParamCreateNewAccountBandwidthRate        ChainParameter = 8
+	ParamAllowCreationOfContracts             ChainParameter = 9
+	ParamRemoveThePowerOfTheGR                ChainParameter = 10
+	ParamEnergyFee                            ChainParameter = 11
+	ParamExchangeCreateFee                    ChainParameter = 12
+	ParamMaxCPUTimeOfOneTx                    ChainParameter = 13
+	ParamAllowUpdateAccountName               ChainParameter = 14
+	ParamAllowSameTokenName                   ChainParameter = 15
+	ParamAllowDelegateResource                ChainParameter = 16
+	ParamTotalEnergyLimit                     ChainParameter = 17
+	ParamAllowTVMTransferTRC10                ChainParameter = 18
+	ParamTotalCurrentEnergyLimit              ChainParameter = 19
+	ParamAllowMultiSign                       ChainParameter = 20
+	ParamAllowAdaptiveEnergy                  ChainParameter = 21
+	ParamUpdateAccountPermissionFee           ChainParameter = 22
+	ParamMultiSignFee                         ChainParameter = 23
+	ParamAllowProtoFilterNum                  ChainParameter = 24
+	ParamAllowAccountStateRoot                ChainParameter = 25
+	ParamAllowTVMConstantinople               ChainParameter = 26
+	ParamAdaptiveResourceLimitMultiplier      ChainParameter = 29
+	ParamAllowChangeDelegation                ChainParameter = 30
+	ParamWitness127PayPerBlock                ChainParameter = 31
+	ParamAllowTVMSolidity059                  ChainParameter = 32
+	ParamAdaptiveResourceLimitTargetRatio     ChainParameter = 33
+	ParamForbidTransferToContract             ChainParameter = 35
+	ParamAllowShieldedTRC20Transaction        ChainParameter = 39
+	ParamAllowPBFT                            ChainParameter = 40
+	ParamAllowTVMIstanbul                     ChainParameter = 41
+	ParamAllowMarketTransaction               ChainParameter = 44
+	ParamMarketSellFee                        ChainParameter = 45
+	ParamMarketCancelFee                      ChainParameter = 46
+	ParamMaxFeeLimit                          ChainParameter = 47
+	ParamAllowTransactionFeePool              ChainParameter = 48
+	ParamAllowBlackholeOptimization           ChainParameter = 49
+	ParamAllowNewResourceModel                ChainParameter = 51
+	ParamAllowTVMFreeze                       ChainParameter = 52
+	ParamAllowAccountAssetOptimization        ChainParameter = 53
+	ParamAllowTVMVote                         ChainParameter = 59
+	ParamAllowTVMCompatibleEVM                ChainParameter = 60
+	ParamFreeNetLimit                         ChainParameter = 61
+	ParamTotalNetLimit                        ChainParameter = 62
+	ParamAllowTVMLondon                       ChainParameter = 63
+	ParamAllowHigherLimitForMaxCPUTimeOfOneTx ChainParameter = 65
+	ParamAllowAssetOptimization               ChainParameter = 66
+	ParamAllowNewReward                       ChainParameter = 67
+	ParamMemoFee                              ChainParameter = 68
+	ParamAllowDelegateOptimization            ChainParameter = 69
+	ParamUnfreezeDelayDays                    ChainParameter = 70
+	ParamAllowOptimizedReturnValueOfChainID   ChainParameter = 71
+	ParamAllowDynamicEnergy                   ChainParameter = 72
+	ParamDynamicEnergyThreshold               ChainParameter = 73
+	ParamDynamicEnergyIncreaseFactor          ChainParameter = 74
+	ParamDynamicEnergyMaxFactor               ChainParameter = 75
+	ParamAllowTVMShanghai                     ChainParameter = 76
+	ParamAllowCancelAllUnfreezeV2             ChainParameter = 77
+	ParamMaxDelegateLockPeriod                ChainParameter = 78
+)
+
+// chainParameterMaxValue upper bound of fee and limit parameters
+const chainParameterMaxValue = 100_000_000_000_000_000
+
+// kinds of value accepted by a chain parameter
+const (
+	paramRange  = iota // Min <= value <= Max
+	paramEnable        // one way activation, only 1
+	paramSwitch        // 0 or 1
+)
+
+// chainParameterSpec name and accepted values of a chain parameter
+type chainParameterSpec struct {
+	name string
+	kind int
+	min  int64
+	max  int64
+}
+
+func rangeParam(name string, min, max int64) chainParameterSpec {
+	return chainParameterSpec{name: name, kind: paramRange, min: min, max: max}
+}
+
+func feeParam(name string) chainParameterSpec {
+	return rangeParam(name, 0, chainParameterMaxValue)
+}
+
+func enableParam(name string) chainParameterSpec {
+	return chainParameterSpec{name: name, kind: paramEnable, min: 1, max: 1}
+}
+
+func switchParam(name string) chainParameterSpec {
+	return chainParameterSpec{name: name, kind: paramSwitch, min: 0, max: 1}
+}
+
+var chainParameters = map[ChainParameter]chainParameterSpec{
+	ParamMaintenanceTimeInterval:              rangeParam("MAINTENANCE_TIME_INTERVAL", 3*27*1000, 24*3600*1000),
+	ParamAccountUpgradeCost:                   feeParam("ACCOUNT_UPGRADE_COST"),
+	ParamCreateAccountFee:                     feeParam("CREATE_ACCOUNT_FEE"),
+	ParamTransactionFee:                       feeParam("TRANSACTION_FEE"),
+	ParamAssetIssueFee:                        feeParam("ASSET_ISSUE_FEE"),
+	ParamWitnessPayPerBlock:                   feeParam("WITNESS_PAY_PER_BLOCK"),
+	ParamWitnessStandbyAllowance:              feeParam("WITNESS_STANDBY_ALLOWANCE"),
+	ParamCreateNewAccountFeeInSystemContract:  feeParam("CREATE_NEW_ACCOUNT_FEE_IN_SYSTEM_CONTRACT"),
+	ParamCreateNewAccountBandwidthRate:        feeParam("CREATE_NEW_ACCOUNT_BANDWIDTH_RATE"),
+	ParamAllowCreationOfContracts:             enableParam("ALLOW_CREATION_OF_CONTRACTS"),
+	ParamRemoveThePowerOfTheGR:                enableParam("REMOVE_THE_POWER_OF_THE_GR"),
+	ParamEnergyFee:                            feeParam("ENERGY_FEE"),
+	ParamExchangeCreateFee:                    feeParam("EXCHANGE_CREATE_FEE"),
+	ParamMaxCPUTimeOfOneTx:                    rangeParam("MAX_CPU_TIME_OF_ONE_TX", 10, 400),
+	ParamAllowUpdateAccountName:               enableParam("ALLOW_UPDATE_ACCOUNT_NAME"),
+	ParamAllowSameTokenName:                   enableParam("ALLOW_SAME_TOKEN_NAME"),
+	ParamAllowDelegateResource:                enableParam("ALLOW_DELEGATE_RESOURCE"),
+	ParamTotalEnergyLimit:                     feeParam("TOTAL_ENERGY_LIMIT"),
+	ParamAllowTVMTransferTRC10:                enableParam("ALLOW_TVM_TRANSFER_TRC10"),
+	ParamTotalCurrentEnergyLimit:              feeParam("TOTAL_CURRENT_ENERGY_LIMIT"),
+	ParamAllowMultiSign:                       enableParam("ALLOW_MULTI_SIGN"),
+	ParamAllowAdaptiveEnergy:                  enableParam("ALLOW_ADAPTIVE_ENERGY"),
+	ParamUpdateAccountPermissionFee:           feeParam("UPDATE_ACCOUNT_PERMISSION_FEE"),
+	ParamMultiSignFee:                         feeParam("MULTI_SIGN_FEE"),
+	ParamAllowProtoFilterNum:                  switchParam("ALLOW_PROTO_FILTER_NUM"),
+	ParamAllowAccountStateRoot:                switchParam("ALLOW_ACCOUNT_STATE_ROOT"),
+	ParamAllowTVMConstantinople:               enableParam("ALLOW_TVM_CONSTANTINOPLE"),
+	ParamAdaptiveResourceLimitMultiplier:      rangeParam("ADAPTIVE_RESOURCE_LIMIT_MULTIPLIER", 1, 10_000),
+	ParamAllowChangeDelegation:                switchParam("ALLOW_CHANGE_DELEGATION"),
+	ParamWitness127PayPerBlock:                feeParam("WITNESS_127_PAY_PER_BLOCK"),
+	ParamAllowTVMSolidity059:                  enableParam("ALLOW_TVM_SOLIDITY_059"),
+	ParamAdaptiveResourceLimitTargetRatio:     rangeParam("ADAPTIVE_RESOURCE_LIMIT_TARGET_RATIO", 1, 1_000),
+	ParamForbidTransferToContract:             enableParam("FORBID_TRANSFER_TO_CONTRACT"),
+	ParamAllowShieldedTRC20Transaction:        switchParam("ALLOW_SHIELDED_TRC20_TRANSACTION"),
+	ParamAllowPBFT:                            enableParam("ALLOW_PBFT"),
+	ParamAllowTVMIstanbul:                     enableParam("ALLOW_TVM_ISTANBUL"),
+	ParamAllowMarketTransaction:               enableParam("ALLOW_MARKET_TRANSACTION"),
+	ParamMarketSellFee:                        rangeParam("MARKET_SELL_FEE", 0, 10_000_000_000),
+	ParamMarketCancelFee:                      rangeParam("MARKET_CANCEL_FEE", 0, 10_000_000_000),
+	ParamMaxFeeLimit:                          rangeParam("MAX_FEE_LIMIT", 0, 10_000_000_000),
+	ParamAllowTransactionFeePool:              switchParam("ALLOW_TRANSACTION_FEE_POOL"),
+	ParamAllowBlackholeOptimization:           enableParam("ALLOW_BLACKHOLE_OPTIMIZATION"),
+	ParamAllowNewResourceModel:                enableParam("ALLOW_NEW_RESOURCE_MODEL"),
+	ParamAllowTVMFreeze:                       enableParam("ALLOW_TVM_FREEZE"),
+	ParamAllowAccountAssetOptimization:        enableParam("ALLOW_ACCOUNT_ASSET_OPTIMIZATION"),
+	ParamAllowTVMVote:                         enableParam("ALLOW_TVM_VOTE"),
+	ParamAllowTVMCompatibleEVM:                enableParam("ALLOW_TVM_COMPATIBLE_EVM"),
+	ParamFreeNetLimit:                         rangeParam("FREE_NET_LIMIT", 0, 100_000),
+	ParamTotalNetLimit:                        rangeParam("TOTAL_NET_LIMIT", 0, 1_000_000_000_000),
+	ParamAllowTVMLondon:                       enableParam("ALLOW_TVM_LONDON"),
+	ParamAllowHigherLimitForMaxCPUTimeOfOneTx: enableParam("ALLOW_HIGHER_LIMIT_FOR_MAX_CPU_TIME_OF_ONE_TX"),
+	ParamAllowAssetOptimization:               enableParam("ALLOW_ASSET_OPTIMIZATION"),
+	ParamAllowNewReward:                       enableParam("ALLOW_NEW_REWARD"),
+	ParamMemoFee:                              rangeParam("MEMO_FEE", 0, 1_000_000_000),
+	ParamAllowDelegateOptimization:            enableParam("ALLOW_DELEGATE_OPTIMIZATION"),
+	ParamUnfreezeDelayDays:                    rangeParam("UNFREEZE_DELAY_DAYS", 1, 365),
+	ParamAllowOptimizedReturnValueOfChainID:   enableParam("ALLOW_OPTIMIZED_RETURN_VALUE_OF_CHAIN_ID"),
+	ParamAllowDynamicEnergy:                   switchParam("ALLOW_DYNAMIC_ENERGY"),
+	ParamDynamicEnergyThreshold:               feeParam("DYNAMIC_ENERGY_THRESHOLD"),
+	ParamDynamicEnergyIncreaseFactor:          rangeParam("DYNAMIC_ENERGY_INCREASE_FACTOR", 0, 10_000),
+	ParamDynamicEnergyMaxFactor:               rangeParam("DYNAMIC_ENERGY_MAX_FACTOR", 0, 100_000),
+	ParamAllowTVMShanghai:                     enableParam("ALLOW_TVM_SHANGHAI"),
+	ParamAllowCancelAllUnfreezeV2:             enableParam("ALLOW_CANCEL_ALL_UNFREEZE_V2"),
+	ParamMaxDelegateLockPeriod:                rangeParam("MAX_DELEGATE_LOCK_PERIOD", 0, chainParameterMaxValue),
+}
+
+// String returns the parameter name, or its id when unknown
+func (p ChainParameter) String() string {
+	if spec, ok := chainParameters[p]; ok {
+		return spec.name
+	}
+	return fmt.Sprintf("PARAMETER_%d", int64(p))
+}
+
+// Known returns true for parameters of this list
+func (p ChainParameter) Known() bool {
+	_, ok := chainParameters[p]
+	return ok
+}
+
+// Validate check value against the range accepted by the node
+func (p ChainParameter) Validate(value int64) error {
+	spec, ok := chainParameters[p]
+	if !ok {
+		return fmt.Errorf("unknown chain parameter %d", int64(p))
+	}
+	switch spec.kind {
+	case paramEnable:
+		if value != 1 {
+			return fmt.Errorf("%s can only be enabled with 1", spec.name)
+		}
+	case paramSwitch:
+		if value != 0 && value != 1 {
+			return fmt.Errorf("%s must be 0 or 1", spec.name)
+		}
+	default:
+		if value < spec.min || value > spec.max {
+			return fmt.Errorf("%s must be in [%d, %d]", spec.name, spec.min, spec.max)
+		}
+	}
+	return nil
+}
+
+// ChainParameterByName returns the parameter of a name
+func ChainParameterByName(name string) (ChainParameter, error) {
+	for p, spec := range chainParameters {
+		if spec.name == name {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown chain parameter %s", name)
+}
+
+// ChainParameters returns every known parameter ordered by id
+func ChainParameters() []ChainParameter {
+	params := make([]ChainParameter, 0, len(chainParameters))
+	for p := range chainParameters {
+		params = append(params, p)
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i] < params[j] })
+	return params
+}
@@ -0,0 +1,77 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainParameter_Validate(t *testing.T) {
+	assert.Equal(t, "ENERGY_FEE", client.ParamEnergyFee.String())
+	assert.Equal(t, "PARAMETER_1000", client.ChainParameter(1000).String())
+
+	p, err := client.ChainParameterByName("MAX_CPU_TIME_OF_ONE_TX")
+	require.Nil(t, err)
+	assert.Equal(t, client.ParamMaxCPUTimeOfOneTx, p)
+
+	assert.Nil(t, client.ParamEnergyFee.Validate(420))
+	assert.NotNil(t, client.ParamEnergyFee.Validate(-1))
+	assert.Nil(t, client.ParamAllowTVMLondon.Validate(1))
+	assert.NotNil(t, client.ParamAllowTVMLondon.Validate(0))
+	assert.Nil(t, client.ParamAllowDynamicEnergy.Validate(0))
+	assert.NotNil(t, client.ParamAllowDynamicEnergy.Validate(2))
+	assert.NotNil(t, client.ParamMaxCPUTimeOfOneTx.Validate(5))
+	assert.NotNil(t, client.ChainParameter(1000).Validate(1))
+
+	params := client.ChainParameters()
+	assert.Equal(t, client.ParamMaintenanceTimeInterval, params[0])
+	for i := 1; i < len(params); i++ {
+		assert.Less(t, params[i-1], params[i])
+	}
+}
+
+func TestNewProposalView(t *testing.T) {
+	witnesses := make([]string, 0, 10)
+	approvals := make([][]byte, 0)
+	for i := 0; i < 10; i++ {
+		addr := make(tron.Address, 21)
+		addr[0] = 0x41
+		addr[20] = byte(i + 1)
+		witnesses = append(witnesses, addr.String())
+		if i < 7 {
+			approvals = append(approvals, addr)
+		}
+	}
+	outsider := make(tron.Address, 21)
+	outsider[0] = 0x41
+	outsider[1] = 0xff
+	approvals = append(approvals, outsider)
+
+	proposal := &core.Proposal{
+		ProposalId:     12,
+		Parameters:     map[int64]int64{11: 420, 0: 21600000},
+		CreateTime:     1600000000000,
+		ExpirationTime: 1600259200000,
+		Approvals:      approvals,
+		State:          core.Proposal_PENDING,
+	}
+	view := client.NewProposalView(proposal, witnesses, time.Unix(1600000001, 0))
+	assert.Equal(t, int64(12), view.ID)
+	require.Len(t, view.Parameters, 2)
+	assert.Equal(t, "MAINTENANCE_TIME_INTERVAL", view.Parameters[0].Name)
+	assert.Equal(t, client.ParamEnergyFee, view.Parameters[1].Parameter)
+	assert.Len(t, view.Approvals, 8)
+	assert.Equal(t, 7, view.ActiveApprovals)
+	assert.Equal(t, 7, view.RequiredApprovals)
+	assert.True(t, view.Passing())
+	assert.True(t, view.Pending())
+	assert.False(t, view.Expired)
+
+	view = client.NewProposalView(proposal, witnesses, time.Unix(1600259200, 0))
+	assert.True(t, view.Expired)
+}
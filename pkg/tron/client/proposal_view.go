@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+)
+
+// ProposalParameter typed parameter change of a proposal
+type ProposalParameter struct {
+	Parameter ChainParameter
+	Name      string
+	Value     int64
+}
+
+// ProposalView proposal with its approval progress
+type ProposalView struct {
+	ID             int64
+	Proposer       string
+	Parameters     []ProposalParameter
+	CreateTime     time.Time
+	ExpirationTime time.Time
+	State          string
+	Approvals      []string
+	// ActiveApprovals approvals from currently active witnesses, the only ones counted
+	ActiveApprovals   int
+	RequiredApprovals int
+	// Expired pending proposal past its expiration, decided at the next maintenance
+	Expired bool
+}
+
+// Pending returns true while the proposal is not decided
+func (p *ProposalView) Pending() bool {
+	return p.State == core.Proposal_PENDING.String()
+}
+
+// Passing returns true when the proposal has enough active approvals
+func (p *ProposalView) Passing() bool {
+	return p.ActiveApprovals >= p.RequiredApprovals
+}
+
+// NewProposalView build the view of a proposal, the node approves a proposal
+// backed by 70% of the active witnesses (base58 addresses)
+func NewProposalView(proposal *core.Proposal, activeWitnesses []string, now time.Time) *ProposalView {
+	view := &ProposalView{
+		ID:                proposal.GetProposalId(),
+		Proposer:          tron.Address(proposal.GetProposerAddress()).String(),
+		Parameters:        make([]ProposalParameter, 0, len(proposal.GetParameters())),
+		CreateTime:        time.Unix(0, proposal.GetCreateTime()*int64(time.Millisecond)),
+		ExpirationTime:    time.Unix(0, proposal.GetExpirationTime()*int64(time.Millisecond)),
+		State:             proposal.GetState().String(),
+		Approvals:         make([]string, 0, len(proposal.GetApprovals())),
+		RequiredApprovals: len(activeWitnesses) * 7 / 10,
+	}
+	for id, value := range proposal.GetParameters() {
+		view.Parameters = append(view.Parameters, ProposalParameter{
+			Parameter: ChainParameter(id),
+			Name:      ChainParameter(id).String(),
+			Value:     value,
+		})
+	}
+	sort.Slice(view.Parameters, func(i, j int) bool {
+		return view.Parameters[i].Parameter < view.Parameters[j].Parameter
+	})
+
+	active := make(map[string]bool, len(activeWitnesses))
+	for _, witness := range activeWitnesses {
+		active[witness] = true
+	}
+	for _, approval := range proposal.GetApprovals() {
+		address := tron.Address(approval).String()
+		view.Approvals = append(view.Approvals, address)
+		if active[address] {
+			view.ActiveApprovals++
+		}
+	}
+	view.Expired = view.Pending() && !now.Before(view.ExpirationTime)
+	return view
+}
+
+// ProposalCreateParams create a proposal from typed parameters, values are
+// validated locally
+func (g *GrpcClient) ProposalCreateParams(from string, parameters map[ChainParameter]int64) (*api.TransactionExtention, error) {
+	if len(parameters) == 0 {
+		return nil, fmt.Errorf("proposal without parameters")
+	}
+	raw := make(map[int64]int64, len(parameters))
+	for p, value := range parameters {
+		if err := p.Validate(value); err != nil {
+			return nil, err
+		}
+		raw[int64(p)] = value
+	}
+	return g.ProposalCreate(from, raw)
+}
+
+// GetProposalByID returns a proposal
+func (g *GrpcClient) GetProposalByID(id int64) (*core.Proposal, error) {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(id))
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	proposal, err := g.Client.GetProposalById(ctx, GetMessageBytes(value))
+	if err != nil {
+		return nil, err
+	}
+	if proposal.GetProposalId() != id {
+		return nil, fmt.Errorf("proposal %d not found", id)
+	}
+	return proposal, nil
+}
+
+// GetPaginatedProposalList returns a page of proposals
+func (g *GrpcClient) GetPaginatedProposalList(offset, limit int64) (*api.ProposalList, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	return g.Client.GetPaginatedProposalList(ctx, GetPaginatedMessage(offset, limit))
+}
+
+// activeWitnesses returns the base58 addresses of the producing witnesses
+func (g *GrpcClient) activeWitnesses() ([]string, error) {
+	witnesses, err := g.ListWitnesses()
+	if err != nil {
+		return nil, err
+	}
+	active := make([]string, 0)
+	for _, witness := range witnesses.GetWitnesses() {
+		if witness.GetIsJobs() {
+			active = append(active, tron.Address(witness.GetAddress()).String())
+		}
+	}
+	return active, nil
+}
+
+// GetProposalView returns a proposal with its approval progress
+func (g *GrpcClient) GetProposalView(id int64) (*ProposalView, error) {
+	proposal, err := g.GetProposalByID(id)
+	if err != nil {
+		return nil, err
+	}
+	active, err := g.activeWitnesses()
+	if err != nil {
+		return nil, err
+	}
+	return NewProposalView(proposal, active, time.Now()), nil
+}
+
+// TrackProposal poll a proposal every interval, handler receives the view
+// each time approvals or state change, returns the decided proposal
+func (g *GrpcClient) TrackProposal(ctx context.Context, id int64, interval time.Duration, handler func(*ProposalView)) (*ProposalView, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *ProposalView
+	for {
+		view, err := g.GetProposalView(id)
+		if err != nil {
+			return nil, err
+		}
+		if last == nil || last.State != view.State || len(last.Approvals) != len(view.Approvals) {
+			if handler != nil {
+				handler(view)
+			}
+			last = view
+		}
+		if !view.Pending() {
+			return view, nil
+		}
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+)
+
+// TRC1155Token is a TRC1155 (multi token) contract bound to a client
+type TRC1155Token struct {
+	client  *GrpcClient
+	address string
+}
+
+// NewTRC1155Token bind a TRC1155 contract
+func NewTRC1155Token(g *GrpcClient, contractAddress string) (*TRC1155Token, error) {
+	if _, err := tron.Base58ToAddress(contractAddress); err != nil {
+		return nil, fmt.Errorf("invalid contract address %s: %v", contractAddress, err)
+	}
+	return &TRC1155Token{client: g, address: contractAddress}, nil
+}
+
+// Address of the token contract
+func (t *TRC1155Token) Address() string {
+	return t.address
+}
+
+// BalanceOf token id owned by address
+func (t *TRC1155Token) BalanceOf(addr string, id *big.Int) (*big.Int, error) {
+	values, err := t.client.constantCall(t.address, "balanceOf(address,uint256)",
+		[]abi.Param{{"address": addr}, {"uint256": id}}, "uint256")
+	if err != nil {
+		return nil, err
+	}
+	return values[0].(*big.Int), nil
+}
+
+// BalanceOfBatch returns the balance of each (address, id) pair
+func (t *TRC1155Token) BalanceOfBatch(addrs []string, ids []*big.Int) ([]*big.Int, error) {
+	if len(addrs) != len(ids) {
+		return nil, fmt.Errorf("addresses and ids length mismatch %d/%d", len(addrs), len(ids))
+	}
+	values, err := t.client.constantCall(t.address, "balanceOfBatch(address[],uint256[])",
+		[]abi.Param{{"address[]": addrs}, {"uint256[]": ids}}, "uint256[]")
+	if err != nil {
+		return nil, err
+	}
+	return values[0].([]*big.Int), nil
+}
+
+// URI of token id, clients replace {id} with the hex id
+func (t *TRC1155Token) URI(id *big.Int) (string, error) {
+	values, err := t.client.constantCall(t.address, "uri(uint256)",
+		[]abi.Param{{"uint256": id}}, "string")
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+// IsApprovedForAll returns true when operator manages all owner tokens
+func (t *TRC1155Token) IsApprovedForAll(owner, operator string) (bool, error) {
+	values, err := t.client.constantCall(t.address, "isApprovedForAll(address,address)",
+		[]abi.Param{{"address": owner}, {"address": operator}}, "bool")
+	if err != nil {
+		return false, err
+	}
+	return values[0].(bool), nil
+}
+
+// SafeTransferFrom move amount of token id from owner to address
+func (t *TRC1155Token) SafeTransferFrom(from, owner, to string, id, amount *big.Int, data []byte, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.client.contractCall(from, t.address, "safeTransferFrom(address,address,uint256,uint256,bytes)", []abi.Param{
+		{"address": owner},
+		{"address": to},
+		{"uint256": id},
+		{"uint256": amount},
+		{"bytes": data},
+	}, feeLimit)
+}
+
+// SafeBatchTransferFrom move amounts of token ids from owner to address
+func (t *TRC1155Token) SafeBatchTransferFrom(from, owner, to string, ids, amounts []*big.Int, data []byte, feeLimit int64) (*api.TransactionExtention, error) {
+	if len(ids) != len(amounts) {
+		return nil, fmt.Errorf("ids and amounts length mismatch %d/%d", len(ids), len(amounts))
+	}
+	return t.client.contractCall(from, t.address, "safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)", []abi.Param{
+		{"address": owner},
+		{"address": to},
+		{"uint256[]": ids},
+		{"uint256[]": amounts},
+		{"bytes": data},
+	}, feeLimit)
+}
+
+// SetApprovalForAll grant or revoke operator on all the caller tokens
+func (t *TRC1155Token) SetApprovalForAll(from, operator string, approved bool, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.client.contractCall(from, t.address, "setApprovalForAll(address,bool)", []abi.Param{
+		{"address": operator},
+		{"bool": approved},
+	}, feeLimit)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/EntySquare/chain-util/pkg/tron"
 	"github.com/EntySquare/chain-util/pkg/tron/common"
@@ -22,6 +23,10 @@ const (
 	trc20BalanceOf               = "0x70a08231"
 )
 
+// ErrNoConstantResult is returned when a constant call reverted or returned
+// no data, for optional TRC20 properties the contract does not implement
+var ErrNoConstantResult = errors.New("no constant result")
+
 // TRC20Call make cosntant calll
 func (g *GrpcClient) TRC20Call(from, contractAddress, data string, constant bool, feeLimit int64) (*api.TransactionExtention, error) {
 	var err error
@@ -63,32 +68,48 @@ func (g *GrpcClient) TRC20Call(from, contractAddress, data string, constant bool
 
 // TRC20GetName get token name
 func (g *GrpcClient) TRC20GetName(contractAddress string) (string, error) {
-	result, err := g.TRC20Call("", contractAddress, trc20NameSignature, true, 0)
+	data, err := g.trc20ConstantCall(contractAddress, trc20NameSignature)
 	if err != nil {
 		return "", err
 	}
-	data := common.BytesToHexString(result.GetConstantResult()[0])
-	return g.ParseTRC20StringProperty(data)
+	return g.ParseTRC20StringProperty(common.BytesToHexString(data))
 }
 
 // TRC20GetSymbol get contract symbol
 func (g *GrpcClient) TRC20GetSymbol(contractAddress string) (string, error) {
-	result, err := g.TRC20Call("", contractAddress, trc20SymbolSignature, true, 0)
+	data, err := g.trc20ConstantCall(contractAddress, trc20SymbolSignature)
 	if err != nil {
 		return "", err
 	}
-	data := common.BytesToHexString(result.GetConstantResult()[0])
-	return g.ParseTRC20StringProperty(data)
+	return g.ParseTRC20StringProperty(common.BytesToHexString(data))
 }
 
 // TRC20GetDecimals get contract decimals
 func (g *GrpcClient) TRC20GetDecimals(contractAddress string) (*big.Int, error) {
-	result, err := g.TRC20Call("", contractAddress, trc20DecimalsSignature, true, 0)
+	data, err := g.trc20ConstantCall(contractAddress, trc20DecimalsSignature)
 	if err != nil {
 		return nil, err
 	}
-	data := common.BytesToHexString(result.GetConstantResult()[0])
-	return g.ParseTRC20NumericProperty(data)
+	return g.ParseTRC20NumericProperty(common.BytesToHexString(data))
+}
+
+// trc20ConstantCall returns the first constant result of a call, errors of a
+// node that ran the call wrap ErrNoConstantResult
+func (g *GrpcClient) trc20ConstantCall(contractAddress, data string) ([]byte, error) {
+	result, err := g.TRC20Call("", contractAddress, data, true, 0)
+	if result == nil {
+		return nil, err
+	}
+	if err == nil {
+		err = constantCallError(result)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoConstantResult, err)
+	}
+	if len(result.GetConstantResult()) == 0 || len(result.GetConstantResult()[0]) == 0 {
+		return nil, ErrNoConstantResult
+	}
+	return result.GetConstantResult()[0], nil
 }
 
 // ParseTRC20NumericProperty get number from data
@@ -150,12 +171,11 @@ func (g *GrpcClient) TRC20ContractBalance(addr, contractAddress string) (*big.In
 		return nil, fmt.Errorf("invalid address %s: %v", addr, addr)
 	}
 	req := trc20BalanceOf + "0000000000000000000000000000000000000000000000000000000000000000"[len(addrB.Hex())-2:] + addrB.Hex()[2:]
-	result, err := g.TRC20Call("", contractAddress, req, true, 0)
+	data, err := g.trc20ConstantCall(contractAddress, req)
 	if err != nil {
 		return nil, err
 	}
-	data := common.BytesToHexString(result.GetConstantResult()[0])
-	r, err := g.ParseTRC20NumericProperty(data)
+	r, err := g.ParseTRC20NumericProperty(common.BytesToHexString(data))
 	if err != nil {
 		return nil, fmt.Errorf("contract address %s: %v", contractAddress, err)
 	}
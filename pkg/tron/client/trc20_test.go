@@ -1,8 +1,16 @@
 package client_test
 
 import (
+	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
+
 	"github.com/EntySquare/chain-util/pkg/tron/client"
+	"github.com/EntySquare/chain-util/pkg/tron/common/numeric"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,3 +32,96 @@ func TestTRC20_Balance(t *testing.T) {
 
 	fmt.Println(balance)
 }
+
+func TestTRC20_Amount(t *testing.T) {
+	amount, err := client.TRC20ToAmount(big.NewInt(1234567), 6)
+	require.Nil(t, err)
+	assert.Equal(t, "1.234567000000000000", amount.String())
+
+	raw, err := client.TRC20FromAmount(numeric.MustNewDecFromStr("1.5"), 6)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1500000), raw.Int64())
+
+	_, err = client.TRC20FromAmount(numeric.MustNewDecFromStr("0.0000001"), 6)
+	assert.NotNil(t, err)
+	_, err = client.TRC20FromAmount(numeric.MustNewDecFromStr("-1"), 6)
+	assert.NotNil(t, err)
+	_, err = client.TRC20ToAmount(big.NewInt(1), 19)
+	assert.NotNil(t, err)
+}
+
+func TestTRC20Token_Metadata(t *testing.T) {
+	conn := client.NewGrpcClient("grpc.trongrid.io:50051")
+	err := conn.Start(grpc.WithInsecure())
+	require.Nil(t, err)
+
+	token, err := client.NewTRC20Token(conn, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t") // USDT
+	require.Nil(t, err)
+	metadata, err := token.Metadata()
+	require.Nil(t, err)
+	assert.Equal(t, int64(6), metadata.Decimals)
+	assert.Equal(t, "USDT", metadata.Symbol)
+
+	supply, err := token.TotalSupply()
+	require.Nil(t, err)
+	assert.True(t, supply.IsPositive())
+}
+
+// tokenNode answers constant calls by method selector
+type tokenNode struct {
+	api.WalletClient
+	results map[string]*api.TransactionExtention
+	// failing selector
+	failing string
+}
+
+func (n *tokenNode) TriggerConstantContract(_ context.Context, ct *core.TriggerSmartContract, _ ...grpc.CallOption) (*api.TransactionExtention, error) {
+	selector := hex.EncodeToString(ct.GetData()[:4])
+	if selector == n.failing {
+		return nil, errors.New("unavailable")
+	}
+	return n.results[selector], nil
+}
+
+func constantResult(data string) *api.TransactionExtention {
+	result, _ := hex.DecodeString(data)
+	return &api.TransactionExtention{Result: &api.Return{}, ConstantResult: [][]byte{result}}
+}
+
+func TestTRC20Token_MetadataErrors(t *testing.T) {
+	reverted := &api.TransactionExtention{
+		Result:      &api.Return{},
+		Transaction: &core.Transaction{Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}}},
+	}
+	node := &tokenNode{results: map[string]*api.TransactionExtention{
+		"313ce567": constantResult("0000000000000000000000000000000000000000000000000000000000000006"),
+		"95d89b41": constantResult("0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000004" +
+			"5553445400000000000000000000000000000000000000000000000000000000"),
+		"06fdde03": reverted,
+		"18160ddd": {Result: &api.Return{}},
+	}}
+	conn := client.NewGrpcClient("trc20-metadata-errors")
+	conn.Client = node
+	token, err := client.NewTRC20Token(conn, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.Nil(t, err)
+
+	// a transient node error is returned and not cached
+	node.failing = "95d89b41"
+	_, err = token.Metadata()
+	assert.NotNil(t, err)
+	node.failing = ""
+
+	// a reverted name is an absent name
+	metadata, err := token.Metadata()
+	require.Nil(t, err)
+	assert.Equal(t, "", metadata.Name)
+	assert.Equal(t, "USDT", metadata.Symbol)
+	assert.Equal(t, int64(6), metadata.Decimals)
+
+	// an empty constant result is an error, not a panic
+	_, err = token.TotalSupply()
+	assert.True(t, errors.Is(err, client.ErrNoConstantResult))
+	_, err = conn.TRC20GetName("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	assert.True(t, errors.Is(err, client.ErrNoConstantResult))
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/common/numeric"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+)
+
+// TRC20Metadata immutable token properties
+type TRC20Metadata struct {
+	Name     string
+	Symbol   string
+	Decimals int64
+}
+
+// trc20Metadata cache keyed by node and contract address
+var trc20Metadata = struct {
+	sync.RWMutex
+	tokens map[string]*TRC20Metadata
+}{tokens: make(map[string]*TRC20Metadata)}
+
+// TRC20Token is a TRC20 contract bound to a client, amounts are expressed
+// as decimals using the token decimals
+type TRC20Token struct {
+	client  *GrpcClient
+	address string
+}
+
+// NewTRC20Token bind a TRC20 contract
+func NewTRC20Token(g *GrpcClient, contractAddress string) (*TRC20Token, error) {
+	if _, err := tron.Base58ToAddress(contractAddress); err != nil {
+		return nil, fmt.Errorf("invalid contract address %s: %v", contractAddress, err)
+	}
+	return &TRC20Token{client: g, address: contractAddress}, nil
+}
+
+// Address of the token contract
+func (t *TRC20Token) Address() string {
+	return t.address
+}
+
+// Metadata returns name, symbol and decimals, loaded once per contract
+func (t *TRC20Token) Metadata() (*TRC20Metadata, error) {
+	key := t.client.Address + "/" + t.address
+	trc20Metadata.RLock()
+	metadata, ok := trc20Metadata.tokens[key]
+	trc20Metadata.RUnlock()
+	if ok {
+		return metadata, nil
+	}
+
+	decimals, err := t.client.TRC20GetDecimals(t.address)
+	if err != nil {
+		return nil, fmt.Errorf("contract address %s: decimals: %v", t.address, err)
+	}
+	if !decimals.IsInt64() || decimals.Int64() > numeric.Precision {
+		return nil, fmt.Errorf("contract address %s: unsupported decimals %s", t.address, decimals)
+	}
+	// name and symbol are optional in the standard, a contract without them
+	// reverts or returns nothing
+	name, err := t.client.TRC20GetName(t.address)
+	if err != nil && !errors.Is(err, ErrNoConstantResult) {
+		return nil, fmt.Errorf("contract address %s: name: %v", t.address, err)
+	}
+	symbol, err := t.client.TRC20GetSymbol(t.address)
+	if err != nil && !errors.Is(err, ErrNoConstantResult) {
+		return nil, fmt.Errorf("contract address %s: symbol: %v", t.address, err)
+	}
+	metadata = &TRC20Metadata{
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: decimals.Int64(),
+	}
+
+	trc20Metadata.Lock()
+	trc20Metadata.tokens[key] = metadata
+	trc20Metadata.Unlock()
+	return metadata, nil
+}
+
+// Name of the token
+func (t *TRC20Token) Name() (string, error) {
+	metadata, err := t.Metadata()
+	if err != nil {
+		return "", err
+	}
+	return metadata.Name, nil
+}
+
+// Symbol of the token
+func (t *TRC20Token) Symbol() (string, error) {
+	metadata, err := t.Metadata()
+	if err != nil {
+		return "", err
+	}
+	return metadata.Symbol, nil
+}
+
+// Decimals of the token
+func (t *TRC20Token) Decimals() (int64, error) {
+	metadata, err := t.Metadata()
+	if err != nil {
+		return 0, err
+	}
+	return metadata.Decimals, nil
+}
+
+// TotalSupply of the token
+func (t *TRC20Token) TotalSupply() (numeric.Dec, error) {
+	return t.callAmount("totalSupply()", nil)
+}
+
+// BalanceOf address
+func (t *TRC20Token) BalanceOf(addr string) (numeric.Dec, error) {
+	return t.callAmount("balanceOf(address)", []abi.Param{{"address": addr}})
+}
+
+// Allowance granted by owner to spender
+func (t *TRC20Token) Allowance(owner, spender string) (numeric.Dec, error) {
+	return t.callAmount("allowance(address,address)", []abi.Param{
+		{"address": owner},
+		{"address": spender},
+	})
+}
+
+// Transfer amount from caller to address
+func (t *TRC20Token) Transfer(from, to string, amount numeric.Dec, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.transact(from, "transfer(address,uint256)", feeLimit, amount, abi.Param{"address": to})
+}
+
+// TransferFrom move amount from owner to address using the caller allowance
+func (t *TRC20Token) TransferFrom(from, owner, to string, amount numeric.Dec, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.transact(from, "transferFrom(address,address,uint256)", feeLimit, amount,
+		abi.Param{"address": owner}, abi.Param{"address": to})
+}
+
+// Approve spender to use amount of the caller tokens
+func (t *TRC20Token) Approve(from, spender string, amount numeric.Dec, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.transact(from, "approve(address,uint256)", feeLimit, amount, abi.Param{"address": spender})
+}
+
+// IncreaseAllowance of spender by amount, the contract must implement the OpenZeppelin extension
+func (t *TRC20Token) IncreaseAllowance(from, spender string, amount numeric.Dec, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.transact(from, "increaseAllowance(address,uint256)", feeLimit, amount, abi.Param{"address": spender})
+}
+
+// DecreaseAllowance of spender by amount, the contract must implement the OpenZeppelin extension
+func (t *TRC20Token) DecreaseAllowance(from, spender string, amount numeric.Dec, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.transact(from, "decreaseAllowance(address,uint256)", feeLimit, amount, abi.Param{"address": spender})
+}
+
+// ToAmount convert raw token units into a decimal amount
+func (t *TRC20Token) ToAmount(raw *big.Int) (numeric.Dec, error) {
+	decimals, err := t.Decimals()
+	if err != nil {
+		return numeric.Dec{}, err
+	}
+	return TRC20ToAmount(raw, decimals)
+}
+
+// FromAmount convert a decimal amount into raw token units
+func (t *TRC20Token) FromAmount(amount numeric.Dec) (*big.Int, error) {
+	decimals, err := t.Decimals()
+	if err != nil {
+		return nil, err
+	}
+	return TRC20FromAmount(amount, decimals)
+}
+
+func (t *TRC20Token) callAmount(method string, param []abi.Param) (numeric.Dec, error) {
+	data, err := abi.Pack(method, param)
+	if err != nil {
+		return numeric.Dec{}, err
+	}
+	result, err := t.client.trc20ConstantCall(t.address, common.BytesToHexString(data))
+	if err != nil {
+		return numeric.Dec{}, fmt.Errorf("contract address %s: %s: %w", t.address, method, err)
+	}
+	raw, err := t.client.ParseTRC20NumericProperty(common.BytesToHexString(result))
+	if err != nil {
+		return numeric.Dec{}, fmt.Errorf("contract address %s: %v", t.address, err)
+	}
+	return t.ToAmount(raw)
+}
+
+// transact build a call to method with param followed by the uint256 amount
+func (t *TRC20Token) transact(from, method string, feeLimit int64, amount numeric.Dec, param ...abi.Param) (*api.TransactionExtention, error) {
+	raw, err := t.FromAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	data, err := abi.Pack(method, append(param, abi.Param{"uint256": raw}))
+	if err != nil {
+		return nil, err
+	}
+	return t.client.TRC20Call(from, t.address, common.BytesToHexString(data), false, feeLimit)
+}
+
+// TRC20ToAmount convert raw token units into a decimal amount
+func TRC20ToAmount(raw *big.Int, decimals int64) (numeric.Dec, error) {
+	if raw == nil {
+		return numeric.Dec{}, fmt.Errorf("invalid amount")
+	}
+	if decimals < 0 || decimals > numeric.Precision {
+		return numeric.Dec{}, fmt.Errorf("unsupported decimals %d", decimals)
+	}
+	return numeric.NewDecFromBigIntWithPrec(raw, decimals), nil
+}
+
+// TRC20FromAmount convert a decimal amount into raw token units, amounts
+// with more fractional digits than decimals are rejected
+func TRC20FromAmount(amount numeric.Dec, decimals int64) (*big.Int, error) {
+	if amount.IsNil() {
+		return nil, fmt.Errorf("invalid amount")
+	}
+	if amount.IsNegative() {
+		return nil, fmt.Errorf("negative amount %s", amount)
+	}
+	if decimals < 0 || decimals > numeric.Precision {
+		return nil, fmt.Errorf("unsupported decimals %d", decimals)
+	}
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(numeric.Precision-decimals), nil)
+	raw, remainder := new(big.Int).QuoRem(amount.Int, multiplier, new(big.Int))
+	if remainder.Sign() != 0 {
+		return nil, fmt.Errorf("amount %s exceeds %d decimals", amount, decimals)
+	}
+	return raw, nil
+}
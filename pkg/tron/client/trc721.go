@@ -0,0 +1,158 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/abi"
+	"github.com/EntySquare/chain-util/pkg/tron/common"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/api"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+)
+
+// TRC721Token is a TRC721 (non fungible) contract bound to a client
+type TRC721Token struct {
+	client  *GrpcClient
+	address string
+}
+
+// NewTRC721Token bind a TRC721 contract
+func NewTRC721Token(g *GrpcClient, contractAddress string) (*TRC721Token, error) {
+	if _, err := tron.Base58ToAddress(contractAddress); err != nil {
+		return nil, fmt.Errorf("invalid contract address %s: %v", contractAddress, err)
+	}
+	return &TRC721Token{client: g, address: contractAddress}, nil
+}
+
+// Address of the token contract
+func (t *TRC721Token) Address() string {
+	return t.address
+}
+
+// OwnerOf token id
+func (t *TRC721Token) OwnerOf(tokenID *big.Int) (string, error) {
+	values, err := t.client.constantCall(t.address, "ownerOf(uint256)",
+		[]abi.Param{{"uint256": tokenID}}, "address")
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+// BalanceOf returns the number of tokens owned by address
+func (t *TRC721Token) BalanceOf(addr string) (*big.Int, error) {
+	values, err := t.client.constantCall(t.address, "balanceOf(address)",
+		[]abi.Param{{"address": addr}}, "uint256")
+	if err != nil {
+		return nil, err
+	}
+	return values[0].(*big.Int), nil
+}
+
+// TokenURI of token id
+func (t *TRC721Token) TokenURI(tokenID *big.Int) (string, error) {
+	values, err := t.client.constantCall(t.address, "tokenURI(uint256)",
+		[]abi.Param{{"uint256": tokenID}}, "string")
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+// GetApproved returns the address approved for token id
+func (t *TRC721Token) GetApproved(tokenID *big.Int) (string, error) {
+	values, err := t.client.constantCall(t.address, "getApproved(uint256)",
+		[]abi.Param{{"uint256": tokenID}}, "address")
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+// IsApprovedForAll returns true when operator manages all owner tokens
+func (t *TRC721Token) IsApprovedForAll(owner, operator string) (bool, error) {
+	values, err := t.client.constantCall(t.address, "isApprovedForAll(address,address)",
+		[]abi.Param{{"address": owner}, {"address": operator}}, "bool")
+	if err != nil {
+		return false, err
+	}
+	return values[0].(bool), nil
+}
+
+// SafeTransferFrom move token id from owner to address, data is passed to
+// the receiver contract and may be empty
+func (t *TRC721Token) SafeTransferFrom(from, owner, to string, tokenID *big.Int, data []byte, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.client.contractCall(from, t.address, "safeTransferFrom(address,address,uint256,bytes)", []abi.Param{
+		{"address": owner},
+		{"address": to},
+		{"uint256": tokenID},
+		{"bytes": data},
+	}, feeLimit)
+}
+
+// TransferFrom move token id from owner to address without receiver checks
+func (t *TRC721Token) TransferFrom(from, owner, to string, tokenID *big.Int, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.client.contractCall(from, t.address, "transferFrom(address,address,uint256)", []abi.Param{
+		{"address": owner},
+		{"address": to},
+		{"uint256": tokenID},
+	}, feeLimit)
+}
+
+// Approve address to transfer token id
+func (t *TRC721Token) Approve(from, to string, tokenID *big.Int, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.client.contractCall(from, t.address, "approve(address,uint256)", []abi.Param{
+		{"address": to},
+		{"uint256": tokenID},
+	}, feeLimit)
+}
+
+// SetApprovalForAll grant or revoke operator on all the caller tokens
+func (t *TRC721Token) SetApprovalForAll(from, operator string, approved bool, feeLimit int64) (*api.TransactionExtention, error) {
+	return t.client.contractCall(from, t.address, "setApprovalForAll(address,bool)", []abi.Param{
+		{"address": operator},
+		{"bool": approved},
+	}, feeLimit)
+}
+
+// constantCall pack method with param, make a constant call and unpack the
+// outputs of the given types, addresses are returned in base58
+func (g *GrpcClient) constantCall(contractAddress, method string, param []abi.Param, outputs ...string) ([]interface{}, error) {
+	data, err := abi.Pack(method, param)
+	if err != nil {
+		return nil, err
+	}
+	result, err := g.TRC20Call("", contractAddress, common.BytesToHexString(data), true, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := constantCallError(result); err != nil {
+		return nil, err
+	}
+	if len(result.GetConstantResult()) == 0 {
+		return nil, fmt.Errorf("contract address %s: empty %s result", contractAddress, method)
+	}
+	params := make([]*core.SmartContract_ABI_Entry_Param, len(outputs))
+	for i, output := range outputs {
+		params[i] = &core.SmartContract_ABI_Entry_Param{Type: output}
+	}
+	arguments, err := abi.NewArguments(params)
+	if err != nil {
+		return nil, err
+	}
+	values, err := arguments.Unpack(result.GetConstantResult()[0])
+	if err != nil {
+		return nil, fmt.Errorf("contract address %s: %s: %v", contractAddress, method, err)
+	}
+	return abi.ConvertAddresses(values), nil
+}
+
+// contractCall pack method with param and build the contract transaction
+func (g *GrpcClient) contractCall(from, contractAddress, method string, param []abi.Param, feeLimit int64) (*api.TransactionExtention, error) {
+	data, err := abi.Pack(method, param)
+	if err != nil {
+		return nil, err
+	}
+	return g.TRC20Call(from, contractAddress, common.BytesToHexString(data), false, feeLimit)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+	"github.com/EntySquare/chain-util/pkg/tron/store"
 
 	// "github.com/ethereum/go-ethereum/crypto"
 
@@ -25,25 +26,59 @@ func checkAndMakeKeyDirIfNeeded() string {
 	return tronCTLDir
 }
 
-func ListKeys(keystoreDir string) {
-	tronCTLDir := checkAndMakeKeyDirIfNeeded()
-	scryptN := keystore.StandardScryptN
-	scryptP := keystore.StandardScryptP
-	ks := keystore.NewKeyStore(tronCTLDir, scryptN, scryptP)
-	// keystore.KeyStore
-	allAccounts := ks.Accounts()
+// defaultKeyDir returns keystoreDir or the default ~/.tronctl/keystore
+func defaultKeyDir(keystoreDir string) string {
+	if keystoreDir != "" {
+		return keystoreDir
+	}
+	userDir, _ := homedir.Dir()
+	return path.Join(userDir, ".tronctl", "keystore")
+}
+
+// Keys returns the accounts of the keystore directory, the default one when empty
+func Keys(keystoreDir string) ([]store.AccountInfo, error) {
+	storage := keystore.NewFileKeyStorage(defaultKeyDir(keystoreDir))
+	if _, err := storage.List(); err != nil {
+		return nil, err
+	}
+	ks := keystore.NewKeyStoreWithStorage(storage, keystore.StandardScryptN, keystore.StandardScryptP)
+	accounts := ks.Accounts()
+	infos := make([]store.AccountInfo, len(accounts))
+	for i, account := range accounts {
+		infos[i] = store.AccountInfo{Address: account.Address.String(), URL: account.URL.String()}
+	}
+	return infos, nil
+}
+
+// NewKey generate a key in the keystore directory, the default one when empty
+func NewKey(keystoreDir, password string) (store.AccountInfo, error) {
+	ks := keystore.NewKeyStore(defaultKeyDir(keystoreDir), keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.NewAccount(password)
+	if err != nil {
+		return store.AccountInfo{}, err
+	}
+	return store.AccountInfo{Address: account.Address.String(), URL: account.URL.String()}, nil
+}
+
+// ListKeys print the accounts of the keystore directory, the default one when empty
+func ListKeys(keystoreDir string) error {
+	if keystoreDir == "" {
+		checkAndMakeKeyDirIfNeeded()
+	}
+	allAccounts, err := Keys(keystoreDir)
+	if err != nil {
+		return err
+	}
 	fmt.Printf("Tron Address:%s File URL:\n", strings.Repeat(" ", tron.AddressLengthBase58))
 	for _, account := range allAccounts {
 		fmt.Printf("%s\t\t %s\n", account.Address, account.URL)
 	}
+	return nil
 }
 
 func AddNewKey(password string) {
-	tronCTLDir := checkAndMakeKeyDirIfNeeded()
-	scryptN := keystore.StandardScryptN
-	scryptP := keystore.StandardScryptP
-	ks := keystore.NewKeyStore(tronCTLDir, scryptN, scryptP)
-	account, err := ks.NewAccount(password)
+	checkAndMakeKeyDirIfNeeded()
+	account, err := NewKey("", password)
 	if err != nil {
 		fmt.Printf("new account error: %v\n", err)
 	}
@@ -8,16 +8,38 @@ import (
 	"github.com/tyler-smith/go-bip39"
 )
 
+// hardenedKeyStart first hardened BIP-32 index, account and index must be below
+const hardenedKeyStart = 0x80000000
+
 // FromMnemonicSeedAndPassphrase derive form mnemonic and passphrase at index
 func FromMnemonicSeedAndPassphrase(mnemonic, passphrase string, index int) (*btcec.PrivateKey, *btcec.PublicKey) {
+	private, _ := derivePath(mnemonic, passphrase, fmt.Sprintf("44'/195'/0'/0/%d", index))
+	return btcec.PrivKeyFromBytes(private[:])
+}
+
+// FromMnemonicAccountIndex derive form mnemonic and passphrase at m/44'/195'/account'/0/index
+func FromMnemonicAccountIndex(mnemonic, passphrase string, account, index uint32) (*btcec.PrivateKey, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+	if account >= hardenedKeyStart || index >= hardenedKeyStart {
+		return nil, fmt.Errorf("account %d and index %d must be below %d", account, index, uint32(hardenedKeyStart))
+	}
+	private, err := derivePath(mnemonic, passphrase, fmt.Sprintf("44'/195'/%d'/0/%d", account, index))
+	if err != nil {
+		return nil, err
+	}
+	key, _ := btcec.PrivKeyFromBytes(private[:])
+	return key, nil
+}
+
+func derivePath(mnemonic, passphrase, path string) ([32]byte, error) {
 	seed := bip39.NewSeed(mnemonic, passphrase)
 	master, ch := hd.ComputeMastersFromSeed(seed, []byte("Bitcoin seed"))
-	private, _ := hd.DerivePrivateKeyForPath(
+	return hd.DerivePrivateKeyForPath(
 		btcec.S256(),
 		master,
 		ch,
-		fmt.Sprintf("44'/195'/0'/0/%d", index),
+		path,
 	)
-
-	return btcec.PrivKeyFromBytes(private[:])
 }
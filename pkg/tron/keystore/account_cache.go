@@ -17,12 +17,10 @@
 package keystore
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/EntySquare/chain-util/pkg/tron"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -64,7 +62,8 @@ func (err *AmbiguousAddrError) Error() string {
 
 // accountCache is a live index of all accounts in the keystore.
 type accountCache struct {
-	keydir   string
+	storage  KeyStorage
+	keydir   string // watched directory, empty unless the storage is a FileKeyStorage
 	watcher  *watcher
 	mu       sync.Mutex
 	all      accountsByURL
@@ -74,12 +73,15 @@ type accountCache struct {
 	fileC    fileCache
 }
 
-func newAccountCache(keydir string) (*accountCache, chan struct{}) {
+func newAccountCache(storage KeyStorage) (*accountCache, chan struct{}) {
 	ac := &accountCache{
-		keydir: keydir,
-		byAddr: make(map[string][]Account),
-		notify: make(chan struct{}, 1),
-		fileC:  fileCache{all: mapset.NewThreadUnsafeSet()},
+		storage: storage,
+		byAddr:  make(map[string][]Account),
+		notify:  make(chan struct{}, 1),
+		fileC:   fileCache{all: mapset.NewThreadUnsafeSet()},
+	}
+	if fs, ok := storage.(*FileKeyStorage); ok {
+		ac.keydir = fs.Dir()
 	}
 	ac.watcher = newWatcher(ac)
 	return ac, ac.notify
@@ -169,7 +171,7 @@ func (ac *accountCache) find(a Account) (Account, error) {
 	if a.URL.Path != "" {
 		// If only the basename is specified, complete the path.
 		if !strings.ContainsRune(a.URL.Path, filepath.Separator) {
-			a.URL.Path = filepath.Join(ac.keydir, a.URL.Path)
+			a.URL.Path = ac.storage.Join(a.URL.Path)
 		}
 		for i := range matches {
 			if matches[i].URL == a.URL {
@@ -210,8 +212,10 @@ func (ac *accountCache) maybeReload() {
 			return // The cache was reloaded recently.
 		}
 	}
-	// No watcher running, start it.
-	ac.watcher.start()
+	// No watcher running, start it. Only directories can be watched.
+	if ac.keydir != "" {
+		ac.watcher.start()
+	}
 	ac.throttle.Reset(minReloadInterval)
 	ac.mu.Unlock()
 	ac.scanAccounts()
@@ -234,7 +238,7 @@ func (ac *accountCache) close() {
 // updates the account cache accordingly
 func (ac *accountCache) scanAccounts() error {
 	// Scan the entire folder metadata for file changes
-	creates, deletes, updates, err := ac.fileC.scan(ac.keydir)
+	creates, deletes, updates, err := ac.fileC.scan(ac.storage)
 	if err != nil {
 		zap.L().Error("Failed to reload keystore contents", zap.Error(err))
 		return err
@@ -244,24 +248,18 @@ func (ac *accountCache) scanAccounts() error {
 		return nil
 	}
 	// Create a helper method to scan the contents of the key files
-	var (
-		buf = new(bufio.Reader)
-		key struct {
-			Address string `json:"address"`
-		}
-	)
+	var key struct {
+		Address string `json:"address"`
+	}
 	readAccount := func(path string) *Account {
-		fd, err := os.Open(path)
+		content, err := ac.storage.Read(path)
 		if err != nil {
-			fmt.Printf("NFailed to open keys: %v", err)
 			zap.L().Error("Failed to open keystore file", zap.String("path", path), zap.Error(err))
 			return nil
 		}
-		defer fd.Close()
-		buf.Reset(fd)
 		// Parse the address.
 		key.Address = ""
-		err = json.NewDecoder(buf).Decode(&key)
+		err = json.Unmarshal(content, &key)
 		addr := tron.HexToAddress(key.Address)
 
 		switch {
@@ -17,9 +17,7 @@
 package keystore
 
 import (
-	"io/ioutil"
 	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -35,13 +33,13 @@ type fileCache struct {
 	mu      sync.RWMutex
 }
 
-// scan performs a new scan on the given directory, compares against the already
-// cached filenames, and returns file sets: creates, deletes, updates.
-func (fc *fileCache) scan(keyDir string) (mapset.Set, mapset.Set, mapset.Set, error) {
+// scan performs a new scan on the given storage, compares against the already
+// cached names, and returns name sets: creates, deletes, updates.
+func (fc *fileCache) scan(storage KeyStorage) (mapset.Set, mapset.Set, mapset.Set, error) {
 	t0 := time.Now()
 
-	// List all the failes from the keystore folder
-	files, err := ioutil.ReadDir(keyDir)
+	// List all the keys from the storage
+	files, err := storage.List()
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -56,18 +54,12 @@ func (fc *fileCache) scan(keyDir string) (mapset.Set, mapset.Set, mapset.Set, er
 
 	var newLastMod time.Time
 	for _, fi := range files {
-		path := filepath.Join(keyDir, fi.Name())
-		// Skip any non-key files from the folder
-		if nonKeyFile(fi) {
-			zap.L().Info("Ignoring file on account scan", zap.String("path", path))
-			continue
-		}
 		// Gather the set of all and fresly modified files
-		all.Add(path)
+		all.Add(fi.Name)
 
-		modified := fi.ModTime()
+		modified := fi.ModTime
 		if modified.After(fc.lastMod) {
-			mods.Add(path)
+			mods.Add(fi.Name)
 		}
 		if modified.After(newLastMod) {
 			newLastMod = modified
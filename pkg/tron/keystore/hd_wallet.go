@@ -0,0 +1,423 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/keys/hd"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/tyler-smith/go-bip39"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	hdWalletVersion = 1
+	// HDKindMnemonic wallet file protecting a BIP39 mnemonic and its passphrase
+	HDKindMnemonic = "mnemonic"
+	// HDKindSeed wallet file protecting a raw BIP39 seed
+	HDKindSeed = "seed"
+)
+
+// DefaultRootDerivationPath TRON BIP44 root, accounts are m/44'/195'/account'/0/index
+var DefaultRootDerivationPath = DerivationPath{0x80000000 + 44, 0x80000000 + 195}
+
+// TronDerivationPath returns m/44'/195'/account'/0/index
+func TronDerivationPath(account, index uint32) DerivationPath {
+	return DerivationPath{0x80000000 + 44, 0x80000000 + 195, 0x80000000 + account, 0, index}
+}
+
+// ParseDerivationPath parse a BIP32 path such as m/44'/195'/0'/0/1
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	parsed, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return DerivationPath(parsed), nil
+}
+
+// String returns the path as m/44'/195'/0'/0/0
+func (path DerivationPath) String() string {
+	return "m/" + path.relative()
+}
+
+// relative returns the path without the m/ prefix
+func (path DerivationPath) relative() string {
+	parts := make([]string, 0, len(path))
+	for _, component := range path {
+		if component >= 0x80000000 {
+			parts = append(parts, fmt.Sprintf("%d'", component-0x80000000))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d", component))
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// hdSecret encrypted content of an HD wallet file
+type hdSecret struct {
+	Mnemonic   string `json:"mnemonic,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+	Seed       string `json:"seed,omitempty"`
+}
+
+type hdPinnedJSON struct {
+	Address string `json:"address"`
+	Path    string `json:"path"`
+}
+
+// hdWalletJSON HD wallet file, the secret is encrypted as keys with EncryptDataV3
+type hdWalletJSON struct {
+	ID      string         `json:"id"`
+	Version int            `json:"version"`
+	Kind    string         `json:"kind"`
+	Crypto  CryptoJSON     `json:"crypto"`
+	Pinned  []hdPinnedJSON `json:"pinned"`
+}
+
+var _ Wallet = (*HDWallet)(nil)
+
+// HDWallet implements Wallet over a BIP39 mnemonic or seed stored encrypted in
+// a single file. The file has no address field, keep it outside of a KeyStore
+// directory
+type HDWallet struct {
+	path string
+	file hdWalletJSON
+
+	mu       sync.RWMutex
+	seed     []byte // nil while closed
+	accounts []Account
+	paths    map[string]DerivationPath // derived accounts by address
+}
+
+// NewHDWallet create the wallet file of a mnemonic, an empty mnemonic
+// generates a new 24 words one
+func NewHDWallet(file, mnemonic, mnemonicPassphrase, auth string, scryptN, scryptP int) (*HDWallet, error) {
+	if mnemonic == "" {
+		entropy, err := bip39.NewEntropy(256)
+		if err != nil {
+			return nil, err
+		}
+		if mnemonic, err = bip39.NewMnemonic(entropy); err != nil {
+			return nil, err
+		}
+	}
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+	return newHDWallet(file, HDKindMnemonic, &hdSecret{Mnemonic: mnemonic, Passphrase: mnemonicPassphrase}, auth, scryptN, scryptP)
+}
+
+// NewHDWalletFromSeed create the wallet file of a BIP39 seed
+func NewHDWalletFromSeed(file string, seed []byte, auth string, scryptN, scryptP int) (*HDWallet, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length %d", len(seed))
+	}
+	return newHDWallet(file, HDKindSeed, &hdSecret{Seed: hex.EncodeToString(seed)}, auth, scryptN, scryptP)
+}
+
+func newHDWallet(file, kind string, secret *hdSecret, auth string, scryptN, scryptP int) (*HDWallet, error) {
+	if _, err := os.Stat(file); err == nil {
+		return nil, fmt.Errorf("wallet file %s already exists", file)
+	}
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return nil, err
+	}
+	cryptoStruct, err := EncryptDataV3(data, []byte(auth), scryptN, scryptP)
+	zeroBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	w := &HDWallet{
+		path: file,
+		file: hdWalletJSON{
+			ID:      uuid.NewRandom().String(),
+			Version: hdWalletVersion,
+			Kind:    kind,
+			Crypto:  cryptoStruct,
+			Pinned:  []hdPinnedJSON{},
+		},
+		paths: make(map[string]DerivationPath),
+	}
+	if err := w.save(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// OpenHDWallet load a wallet file, the wallet stays locked until Open
+func OpenHDWallet(file string) (*HDWallet, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	w := &HDWallet{path: file, paths: make(map[string]DerivationPath)}
+	if err := json.Unmarshal(data, &w.file); err != nil {
+		return nil, fmt.Errorf("invalid wallet file %s: %v", file, err)
+	}
+	if w.file.Version != hdWalletVersion {
+		return nil, fmt.Errorf("wallet version not supported: %v", w.file.Version)
+	}
+	for _, pinned := range w.file.Pinned {
+		path, err := ParseDerivationPath(pinned.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pinned path %s: %v", pinned.Path, err)
+		}
+		address, err := tron.Base58ToAddress(pinned.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pinned address %s: %v", pinned.Address, err)
+		}
+		w.accounts = append(w.accounts, Account{Address: address, URL: w.URL()})
+		w.paths[pinned.Address] = path
+	}
+	return w, nil
+}
+
+// save write the wallet file atomically
+func (w *HDWallet) save() error {
+	content, err := json.MarshalIndent(w.file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeKeyFile(w.path, content)
+}
+
+// decryptSeed returns the BIP39 seed protected by auth
+func (w *HDWallet) decryptSeed(auth string) ([]byte, error) {
+	data, err := DecryptDataV3(w.file.Crypto, auth)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(data)
+	secret := &hdSecret{}
+	if err := json.Unmarshal(data, secret); err != nil {
+		return nil, err
+	}
+	switch w.file.Kind {
+	case HDKindMnemonic:
+		return bip39.NewSeed(secret.Mnemonic, secret.Passphrase), nil
+	case HDKindSeed:
+		return hex.DecodeString(secret.Seed)
+	}
+	return nil, fmt.Errorf("unknown wallet kind %s", w.file.Kind)
+}
+
+// Mnemonic returns the protected mnemonic, empty for seed wallets
+func (w *HDWallet) Mnemonic(auth string) (string, error) {
+	data, err := DecryptDataV3(w.file.Crypto, auth)
+	if err != nil {
+		return "", err
+	}
+	defer zeroBytes(data)
+	secret := &hdSecret{}
+	if err := json.Unmarshal(data, secret); err != nil {
+		return "", err
+	}
+	return secret.Mnemonic, nil
+}
+
+// URL implements Wallet, returning the wallet file location
+func (w *HDWallet) URL() URL {
+	return URL{Scheme: KeyStoreScheme, Path: w.path}
+}
+
+// Status implements Wallet, returning whether the seed is decrypted
+func (w *HDWallet) Status() (string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.seed != nil {
+		return "Unlocked", nil
+	}
+	return "Locked", nil
+}
+
+// Open implements Wallet, decrypting the seed with passphrase
+func (w *HDWallet) Open(passphrase string) error {
+	seed, err := w.decryptSeed(passphrase)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seed != nil {
+		zeroBytes(seed)
+		return ErrWalletAlreadyOpen
+	}
+	w.seed = seed
+	return nil
+}
+
+// Close implements Wallet, wiping the decrypted seed
+func (w *HDWallet) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seed == nil {
+		return ErrWalletClosed
+	}
+	zeroBytes(w.seed)
+	w.seed = nil
+	return nil
+}
+
+// Accounts implements Wallet, returning the pinned accounts
+func (w *HDWallet) Accounts() []Account {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	cpy := make([]Account, len(w.accounts))
+	copy(cpy, w.accounts)
+	return cpy
+}
+
+// Contains implements Wallet, returning whether the account was derived by the wallet
+func (w *HDWallet) Contains(account Account) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	_, ok := w.paths[account.Address.String()]
+	return ok && (account.URL == (URL{}) || account.URL == w.URL())
+}
+
+// derivePrivateKey derive the key at path from seed
+func derivePrivateKey(seed []byte, path DerivationPath) (*ecdsa.PrivateKey, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("empty derivation path")
+	}
+	master, chainCode := hd.ComputeMastersFromSeed(seed, []byte("Bitcoin seed"))
+	private, err := hd.DerivePrivateKeyForPath(btcec.S256(), master, chainCode, path.relative())
+	if err != nil {
+		return nil, err
+	}
+	key, _ := btcec.PrivKeyFromBytes(private[:])
+	return key.ToECDSA(), nil
+}
+
+// Derive implements Wallet, deriving the account at path. Pinned accounts
+// are listed by Accounts and saved in the wallet file
+func (w *HDWallet) Derive(path DerivationPath, pin bool) (Account, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seed == nil {
+		return Account{}, ErrLocked
+	}
+	key, err := derivePrivateKey(w.seed, path)
+	if err != nil {
+		return Account{}, err
+	}
+	defer zeroKey(key)
+
+	account := Account{Address: tron.PublicKeyToAddress(key.PublicKey), URL: w.URL()}
+	address := account.Address.String()
+	if _, ok := w.paths[address]; !ok {
+		w.paths[address] = append(DerivationPath{}, path...)
+	}
+	if !pin {
+		return account, nil
+	}
+	for _, pinned := range w.accounts {
+		if bytes.Equal(pinned.Address, account.Address) {
+			return account, nil
+		}
+	}
+	w.accounts = append(w.accounts, account)
+	w.file.Pinned = append(w.file.Pinned, hdPinnedJSON{Address: address, Path: path.String()})
+	if err := w.save(); err != nil {
+		return Account{}, err
+	}
+	return account, nil
+}
+
+// privateKey returns the key of a derived account using the open seed, or the
+// seed decrypted with passphrase when given
+func (w *HDWallet) privateKey(account Account, passphrase *string) (*ecdsa.PrivateKey, error) {
+	w.mu.RLock()
+	path, ok := w.paths[account.Address.String()]
+	var seed []byte
+	if passphrase == nil && w.seed != nil {
+		seed = append([]byte{}, w.seed...)
+	}
+	w.mu.RUnlock()
+	if !ok || (account.URL != (URL{}) && account.URL != w.URL()) {
+		return nil, ErrUnknownAccount
+	}
+
+	if passphrase != nil {
+		var err error
+		if seed, err = w.decryptSeed(*passphrase); err != nil {
+			return nil, err
+		}
+	} else if seed == nil {
+		return nil, ErrLocked
+	}
+	defer zeroBytes(seed)
+	return derivePrivateKey(seed, path)
+}
+
+func (w *HDWallet) signHash(account Account, passphrase *string, hash []byte) ([]byte, error) {
+	key, err := w.privateKey(account, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key)
+	return crypto.Sign(hash, key)
+}
+
+func (w *HDWallet) signTx(account Account, passphrase *string, tx *core.Transaction) (*core.Transaction, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(rawData)
+	signature, err := w.signHash(account, passphrase, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	tx.Signature = append(tx.Signature, signature)
+	return tx, nil
+}
+
+// SignData implements Wallet, signing keccak256(data)
+func (w *HDWallet) SignData(account Account, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, nil, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase implements Wallet, signing keccak256(data)
+func (w *HDWallet) SignDataWithPassphrase(account Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, &passphrase, crypto.Keccak256(data))
+}
+
+// SignText implements Wallet, signing the TRON message hash of text
+func (w *HDWallet) SignText(account Account, text []byte, useFixedLength ...bool) ([]byte, error) {
+	return w.signHash(account, nil, TextHash(text, useFixedLength...))
+}
+
+// SignTextWithPassphrase implements Wallet, signing the TRON message hash of text
+func (w *HDWallet) SignTextWithPassphrase(account Account, passphrase string, text []byte) ([]byte, error) {
+	return w.signHash(account, &passphrase, TextHash(text))
+}
+
+// SignTx implements Wallet, appending the signature of account to tx
+func (w *HDWallet) SignTx(account Account, tx *core.Transaction) (*core.Transaction, error) {
+	return w.signTx(account, nil, tx)
+}
+
+// SignTxWithPassphrase implements Wallet, appending the signature of account to tx
+func (w *HDWallet) SignTxWithPassphrase(account Account, passphrase string, tx *core.Transaction) (*core.Transaction, error) {
+	return w.signTx(account, &passphrase, tx)
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
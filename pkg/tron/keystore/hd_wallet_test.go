@@ -0,0 +1,57 @@
+package keystore_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/keys"
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const hdTestMnemonic = "bag car educate river behind lumber fee seminar spend air stuff phrase mango basket fine crystal number strong eight what spawn impact crater surprise"
+
+func TestHDWallet(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "hd.json")
+	wallet, err := keystore.NewHDWallet(file, hdTestMnemonic, "", "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.Nil(t, err)
+
+	_, err = wallet.Derive(keystore.TronDerivationPath(0, 0), true)
+	assert.Equal(t, keystore.ErrLocked, err)
+	assert.NotNil(t, wallet.Open("wrong"))
+	require.Nil(t, wallet.Open("secret"))
+
+	account, err := wallet.Derive(keystore.TronDerivationPath(0, 0), true)
+	require.Nil(t, err)
+	private, _ := keys.FromMnemonicSeedAndPassphrase(hdTestMnemonic, "", 0)
+	assert.Equal(t, tron.PublicKeyToAddress(private.ToECDSA().PublicKey), account.Address)
+
+	other, err := wallet.Derive(keystore.TronDerivationPath(1, 5), false)
+	require.Nil(t, err)
+	private, err = keys.FromMnemonicAccountIndex(hdTestMnemonic, "", 1, 5)
+	require.Nil(t, err)
+	assert.Equal(t, tron.PublicKeyToAddress(private.ToECDSA().PublicKey), other.Address)
+	assert.Len(t, wallet.Accounts(), 1)
+	require.Nil(t, wallet.Close())
+
+	reopened, err := keystore.OpenHDWallet(file)
+	require.Nil(t, err)
+	require.Len(t, reopened.Accounts(), 1)
+	assert.Equal(t, account.Address, reopened.Accounts()[0].Address)
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1600000000000}}
+	_, err = reopened.SignTx(account, tx)
+	assert.Equal(t, keystore.ErrLocked, err)
+	_, err = reopened.SignTxWithPassphrase(account, "wrong", tx)
+	assert.NotNil(t, err)
+	signed, err := reopened.SignTxWithPassphrase(account, "secret", tx)
+	require.Nil(t, err)
+	assert.Len(t, signed.GetSignature(), 1)
+
+	mnemonic, err := reopened.Mnemonic("secret")
+	require.Nil(t, err)
+	assert.Equal(t, hdTestMnemonic, mnemonic)
+}
@@ -0,0 +1,170 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KDFScrypt is the scrypt key derivation of the Web3 Secret Storage
+	KDFScrypt = keyHeaderKDF
+	// KDFArgon2id is the argon2id key derivation
+	KDFArgon2id = "argon2id"
+	// KDFPBKDF2 is the legacy pbkdf2 key derivation, only decrypted
+	KDFPBKDF2 = "pbkdf2"
+
+	argon2DKLen = 32
+)
+
+// KDFConfig selects the key derivation used to encrypt keys
+type KDFConfig struct {
+	Name string // KDFScrypt or KDFArgon2id
+
+	ScryptN int
+	ScryptP int
+
+	Argon2Time    uint32 // iterations
+	Argon2Memory  uint32 // KiB
+	Argon2Threads uint8
+}
+
+var (
+	// StandardKDF is scrypt with StandardScryptN and StandardScryptP
+	StandardKDF = ScryptKDF(StandardScryptN, StandardScryptP)
+	// LightKDF is scrypt with LightScryptN and LightScryptP
+	LightKDF = ScryptKDF(LightScryptN, LightScryptP)
+	// Argon2idKDF is argon2id with the RFC 9106 second recommended option,
+	// 3 passes over 64MiB
+	Argon2idKDF = KDFConfig{Name: KDFArgon2id, Argon2Time: 3, Argon2Memory: 64 * 1024, Argon2Threads: 4}
+)
+
+// ScryptKDF returns the scrypt config with N and P
+func ScryptKDF(scryptN, scryptP int) KDFConfig {
+	return KDFConfig{Name: KDFScrypt, ScryptN: scryptN, ScryptP: scryptP}
+}
+
+// String implements fmt.Stringer
+func (c KDFConfig) String() string {
+	switch c.Name {
+	case KDFScrypt:
+		return fmt.Sprintf("scrypt(n=%d,r=%d,p=%d)", c.ScryptN, scryptR, c.ScryptP)
+	case KDFArgon2id:
+		return fmt.Sprintf("argon2id(t=%d,m=%dKiB,p=%d)", c.Argon2Time, c.Argon2Memory, c.Argon2Threads)
+	}
+	return c.Name
+}
+
+// Validate checks the parameters can derive a key
+func (c KDFConfig) Validate() error {
+	switch c.Name {
+	case KDFScrypt:
+		if c.ScryptN <= 1 || c.ScryptN&(c.ScryptN-1) != 0 {
+			return fmt.Errorf("scrypt N must be a power of 2 greater than 1, got %d", c.ScryptN)
+		}
+		if c.ScryptP <= 0 {
+			return fmt.Errorf("scrypt P must be > 0, got %d", c.ScryptP)
+		}
+	case KDFArgon2id:
+		if c.Argon2Time == 0 || c.Argon2Threads == 0 {
+			return fmt.Errorf("argon2id time and threads must be > 0")
+		}
+		if c.Argon2Memory < 8*uint32(c.Argon2Threads) {
+			return fmt.Errorf("argon2id memory must be at least 8KiB per thread, got %dKiB", c.Argon2Memory)
+		}
+	default:
+		return fmt.Errorf("unsupported KDF: %s", c.Name)
+	}
+	return nil
+}
+
+// EncryptDataWithKDF encrypts data with the password auth, deriving the key with kdf
+func EncryptDataWithKDF(data, auth []byte, kdf KDFConfig) (CryptoJSON, error) {
+	if err := kdf.Validate(); err != nil {
+		return CryptoJSON{}, err
+	}
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		panic("reading from crypto/rand failed: " + err.Error())
+	}
+
+	params := make(map[string]interface{}, 5)
+	params["salt"] = hex.EncodeToString(salt)
+	var (
+		derivedKey []byte
+		err        error
+	)
+	switch kdf.Name {
+	case KDFScrypt:
+		derivedKey, err = scrypt.Key(auth, salt, kdf.ScryptN, scryptR, kdf.ScryptP, scryptDKLen)
+		if err != nil {
+			return CryptoJSON{}, err
+		}
+		params["n"] = kdf.ScryptN
+		params["r"] = scryptR
+		params["p"] = kdf.ScryptP
+		params["dklen"] = scryptDKLen
+	case KDFArgon2id:
+		derivedKey = argon2.IDKey(auth, salt, kdf.Argon2Time, kdf.Argon2Memory, kdf.Argon2Threads, argon2DKLen)
+		params["t"] = int(kdf.Argon2Time)
+		params["m"] = int(kdf.Argon2Memory)
+		params["p"] = int(kdf.Argon2Threads)
+		params["dklen"] = argon2DKLen
+	}
+	encryptKey := derivedKey[:16]
+
+	iv := make([]byte, aes.BlockSize) // 16
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		panic("reading from crypto/rand failed: " + err.Error())
+	}
+	cipherText, err := aesCTRXOR(encryptKey, data, iv)
+	if err != nil {
+		return CryptoJSON{}, err
+	}
+	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
+
+	return CryptoJSON{
+		Cipher:       "aes-128-ctr",
+		CipherText:   hex.EncodeToString(cipherText),
+		CipherParams: cipherparamsJSON{IV: hex.EncodeToString(iv)},
+		KDF:          kdf.Name,
+		KDFParams:    params,
+		MAC:          hex.EncodeToString(mac),
+	}, nil
+}
+
+// EncryptKeyWithKDF encrypts a key into a V3 json blob, deriving the
+// encryption key with kdf
+func EncryptKeyWithKDF(key *Key, auth string, kdf KDFConfig) ([]byte, error) {
+	keyBytes := math.PaddedBigBytes(key.PrivateKey.D, 32)
+	defer zeroBytes(keyBytes)
+	cryptoStruct, err := EncryptDataWithKDF(keyBytes, []byte(auth), kdf)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encryptedKeyJSONV3{
+		hex.EncodeToString(key.Address[:]),
+		cryptoStruct,
+		key.ID.String(),
+		version,
+	})
+}
+
+// argon2idKey derives the argon2id key of a CryptoJSON
+func argon2idKey(cryptoJSON CryptoJSON, auth, salt []byte, dkLen int) ([]byte, error) {
+	t := ensureInt(cryptoJSON.KDFParams["t"])
+	m := ensureInt(cryptoJSON.KDFParams["m"])
+	p := ensureInt(cryptoJSON.KDFParams["p"])
+	if t <= 0 || m <= 0 || p <= 0 || p > 255 {
+		return nil, fmt.Errorf("invalid argon2id parameters t=%d m=%d p=%d", t, m, p)
+	}
+	return argon2.IDKey(auth, salt, uint32(t), uint32(m), uint8(p), uint32(dkLen)), nil
+}
@@ -203,7 +203,16 @@ func writeTemporaryKeyFile(file string, content []byte) (string, error) {
 		os.Remove(f.Name())
 		return "", err
 	}
-	f.Close()
+	// the content must be on disk before the rename makes it the key file
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
 	return f.Name(), nil
 }
 
@@ -212,7 +221,11 @@ func writeKeyFile(file string, content []byte) error {
 	if err != nil {
 		return err
 	}
-	return os.Rename(name, file)
+	if err := os.Rename(name, file); err != nil {
+		os.Remove(name)
+		return err
+	}
+	return nil
 }
 
 // keyFileName implements the naming convention for keyfiles:
@@ -222,6 +235,11 @@ func keyFileName(keyAddr tron.Address) string {
 	return fmt.Sprintf("UTC--%s--%s", toISO8601(ts), hex.EncodeToString(keyAddr[:]))
 }
 
+// KeyFileName returns the file name the keystore gives the key of keyAddr
+func KeyFileName(keyAddr tron.Address) string {
+	return keyFileName(keyAddr)
+}
+
 func toISO8601(t time.Time) string {
 	var tz string
 	name, offset := t.Zone()
@@ -27,8 +27,6 @@ import (
 	"crypto/sha256"
 	"errors"
 	"github.com/EntySquare/chain-util/pkg/tron"
-	"os"
-	"path/filepath"
 	"reflect"
 	"runtime"
 	"sync"
@@ -60,8 +58,9 @@ const KeyStoreScheme = "keystore"
 // Maximum time between wallet refreshes (if filesystem notifications don't work).
 const walletRefreshCycle = 3 * time.Second
 
-// KeyStore manages a key storage directory on disk.
+// KeyStore manages the keys of a KeyStorage, by default a directory on disk.
 type KeyStore struct {
+	keys     KeyStorage           // Where the key blobs are persisted
 	storage  keyStore             // Storage backend, might be cleartext or encrypted
 	cache    *accountCache        // In-memory account cache over the filesystem storage
 	changes  chan struct{}        // Channel receiving change notifications from the cache
@@ -84,20 +83,34 @@ type unlocked struct {
 
 // NewKeyStore creates a keystore for the given directory.
 func NewKeyStore(keydir string, scryptN, scryptP int) *KeyStore {
-	keydir, _ = filepath.Abs(keydir)
-	ks := &KeyStore{storage: &keyStorePassphrase{keydir, scryptN, scryptP, false}}
-	ks.init(keydir)
+	return NewKeyStoreWithStorage(NewFileKeyStorage(keydir), scryptN, scryptP)
+}
+
+// NewKeyStoreWithStorage creates a keystore encrypting keys into storage.
+func NewKeyStoreWithStorage(storage KeyStorage, scryptN, scryptP int) *KeyStore {
+	return NewKeyStoreWithKDF(storage, ScryptKDF(scryptN, scryptP))
+}
+
+// NewKeyStoreWithKDF creates a keystore encrypting keys into storage with kdf.
+func NewKeyStoreWithKDF(storage KeyStorage, kdf KDFConfig) *KeyStore {
+	ks := &KeyStore{keys: storage, storage: &keyStorePassphrase{storage, kdf, false}}
+	ks.init()
 	return ks
 }
 
-func (ks *KeyStore) init(keydir string) {
+// Storage returns the storage holding the keys.
+func (ks *KeyStore) Storage() KeyStorage {
+	return ks.keys
+}
+
+func (ks *KeyStore) init() {
 	// Lock the mutex since the account cache might call back with events
 	ks.mu.Lock()
 	defer ks.mu.Unlock()
 
 	// Initialize the set of unlocked keys and the account cache
 	ks.unlocked = make(map[string]*unlocked)
-	ks.cache, ks.changes = newAccountCache(keydir)
+	ks.cache, ks.changes = newAccountCache(ks.keys)
 
 	// TODO: In order for this finalizer to work, there must be no references
 	// to ks. addressCache doesn't keep a reference but unlocked keys do,
@@ -246,7 +259,7 @@ func (ks *KeyStore) Delete(a Account, passphrase string) error {
 	// The order is crucial here. The key is dropped from the
 	// cache after the file is gone so that a reload happening in
 	// between won't insert it into the cache again.
-	err = os.Remove(a.URL.Path)
+	err = ks.keys.Delete(a.URL.Path)
 	if err == nil {
 		ks.cache.delete(a)
 		ks.refreshWallets()
@@ -447,13 +460,12 @@ func (ks *KeyStore) Export(a Account, passphrase, newPassphrase string) (keyJSON
 	if err != nil {
 		return nil, err
 	}
-	var N, P int
+	defer zeroKey(key.PrivateKey)
+	kdf := StandardKDF
 	if store, ok := ks.storage.(*keyStorePassphrase); ok {
-		N, P = store.scryptN, store.scryptP
-	} else {
-		N, P = StandardScryptN, StandardScryptP
+		kdf = store.kdf
 	}
-	return EncryptKey(key, newPassphrase, N, P)
+	return EncryptKeyWithKDF(key, newPassphrase, kdf)
 }
 
 // Import stores the given encrypted JSON key into the key directory.
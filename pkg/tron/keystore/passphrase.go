@@ -27,19 +27,13 @@ package keystore
 
 import (
 	"bytes"
-	"crypto/aes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"github.com/EntySquare/chain-util/pkg/tron"
-	"io"
-	"io/ioutil"
-	"os"
-	"path/filepath"
 
-	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pborman/uuid"
 	"golang.org/x/crypto/pbkdf2"
@@ -70,9 +64,8 @@ const (
 )
 
 type keyStorePassphrase struct {
-	keysDirPath string
-	scryptN     int
-	scryptP     int
+	storage KeyStorage
+	kdf     KDFConfig
 	// skipKeyFileVerification disables the security-feature which does
 	// reads and decrypts any newly created keyfiles. This should be 'false' in all
 	// cases except tests -- setting this to 'true' is not recommended.
@@ -81,7 +74,7 @@ type keyStorePassphrase struct {
 
 func (ks keyStorePassphrase) GetKey(addr tron.Address, filename, auth string) (*Key, error) {
 	// Load the key from the keystore and decrypt its contents
-	keyjson, err := ioutil.ReadFile(filename)
+	keyjson, err := ks.storage.Read(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -98,102 +91,43 @@ func (ks keyStorePassphrase) GetKey(addr tron.Address, filename, auth string) (*
 
 // StoreKey generates a key, encrypts with 'auth' and stores in the given directory
 func StoreKey(dir, auth string, scryptN, scryptP int) (Account, error) {
-	_, a, err := storeNewKey(&keyStorePassphrase{dir, scryptN, scryptP, false}, rand.Reader, auth)
+	_, a, err := storeNewKey(&keyStorePassphrase{NewFileKeyStorage(dir), ScryptKDF(scryptN, scryptP), false}, rand.Reader, auth)
 	return a, err
 }
 
 func (ks keyStorePassphrase) StoreKey(filename string, key *Key, auth string) error {
-	keyjson, err := EncryptKey(key, auth, ks.scryptN, ks.scryptP)
-	if err != nil {
-		return err
-	}
-	// Write into temporary file
-	tmpName, err := writeTemporaryKeyFile(filename, keyjson)
+	keyjson, err := EncryptKeyWithKDF(key, auth, ks.kdf)
 	if err != nil {
 		return err
 	}
 	if !ks.skipKeyFileVerification {
-		// Verify that we can decrypt the file with the given password.
-		_, err = ks.GetKey(key.Address, tmpName, auth)
+		// Verify that we can decrypt the key with the given password
+		// before it replaces anything in the storage.
+		verified, err := DecryptKey(keyjson, auth)
 		if err != nil {
-			msg := "An error was encountered when saving and verifying the keystore file. \n" +
-				"This indicates that the keystore is corrupted. \n" +
-				"The corrupted file is stored at \n%v\n" +
-				"Please file a ticket at:\n\n" +
-				"https://github.com/ethereum/go-ethereum/issues." +
-				"The error was : %s"
-			return fmt.Errorf(msg, tmpName, err)
+			return fmt.Errorf("could not verify the encrypted key for %s: %v", filename, err)
+		}
+		zeroKey(verified.PrivateKey)
+		if !bytes.Equal(verified.Address, key.Address) {
+			return fmt.Errorf("key content mismatch: have account %x, want %x", verified.Address, key.Address)
 		}
 	}
-	return os.Rename(tmpName, filename)
+	return ks.storage.Write(filename, keyjson)
 }
 
 func (ks keyStorePassphrase) JoinPath(filename string) string {
-	if filepath.IsAbs(filename) {
-		return filename
-	}
-	return filepath.Join(ks.keysDirPath, filename)
+	return ks.storage.Join(filename)
 }
 
 // EncryptDataV3 encrypts the data given as 'data' with the password 'auth'.
 func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error) {
-
-	salt := make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
-		panic("reading from crypto/rand failed: " + err.Error())
-	}
-	derivedKey, err := scrypt.Key(auth, salt, scryptN, scryptR, scryptP, scryptDKLen)
-	if err != nil {
-		return CryptoJSON{}, err
-	}
-	encryptKey := derivedKey[:16]
-
-	iv := make([]byte, aes.BlockSize) // 16
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
-		panic("reading from crypto/rand failed: " + err.Error())
-	}
-	cipherText, err := aesCTRXOR(encryptKey, data, iv)
-	if err != nil {
-		return CryptoJSON{}, err
-	}
-	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
-
-	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = scryptN
-	scryptParamsJSON["r"] = scryptR
-	scryptParamsJSON["p"] = scryptP
-	scryptParamsJSON["dklen"] = scryptDKLen
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
-	cipherParamsJSON := cipherparamsJSON{
-		IV: hex.EncodeToString(iv),
-	}
-
-	cryptoStruct := CryptoJSON{
-		Cipher:       "aes-128-ctr",
-		CipherText:   hex.EncodeToString(cipherText),
-		CipherParams: cipherParamsJSON,
-		KDF:          keyHeaderKDF,
-		KDFParams:    scryptParamsJSON,
-		MAC:          hex.EncodeToString(mac),
-	}
-	return cryptoStruct, nil
+	return EncryptDataWithKDF(data, auth, ScryptKDF(scryptN, scryptP))
 }
 
 // EncryptKey encrypts a key using the specified scrypt parameters into a json
 // blob that can be decrypted later on.
 func EncryptKey(key *Key, auth string, scryptN, scryptP int) ([]byte, error) {
-	keyBytes := math.PaddedBigBytes(key.PrivateKey.D, 32)
-	cryptoStruct, err := EncryptDataV3(keyBytes, []byte(auth), scryptN, scryptP)
-	if err != nil {
-		return nil, err
-	}
-	encryptedKeyJSONV3 := encryptedKeyJSONV3{
-		hex.EncodeToString(key.Address[:]),
-		cryptoStruct,
-		key.ID.String(),
-		version,
-	}
-	return json.Marshal(encryptedKeyJSONV3)
+	return EncryptKeyWithKDF(key, auth, ScryptKDF(scryptN, scryptP))
 }
 
 // DecryptKey decrypts a key from a json blob, returning the private key itself.
@@ -331,7 +265,10 @@ func getKDFKey(cryptoJSON CryptoJSON, auth string) ([]byte, error) {
 		p := ensureInt(cryptoJSON.KDFParams["p"])
 		return scrypt.Key(authArray, salt, n, r, p, dkLen)
 
-	} else if cryptoJSON.KDF == "pbkdf2" {
+	} else if cryptoJSON.KDF == KDFArgon2id {
+		return argon2idKey(cryptoJSON, authArray, salt, dkLen)
+
+	} else if cryptoJSON.KDF == KDFPBKDF2 {
 		c := ensureInt(cryptoJSON.KDFParams["c"])
 		prf := cryptoJSON.KDFParams["prf"].(string)
 		if prf != "hmac-sha256" {
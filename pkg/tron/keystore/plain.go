@@ -21,22 +21,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/EntySquare/chain-util/pkg/tron"
-	"os"
-	"path/filepath"
 )
 
 type keyStorePlain struct {
-	keysDirPath string
+	storage KeyStorage
 }
 
 func (ks keyStorePlain) GetKey(addr tron.Address, filename, auth string) (*Key, error) {
-	fd, err := os.Open(filename)
+	content, err := ks.storage.Read(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer fd.Close()
 	key := new(Key)
-	if err := json.NewDecoder(fd).Decode(key); err != nil {
+	if err := json.Unmarshal(content, key); err != nil {
 		return nil, err
 	}
 	if !bytes.Equal(key.Address, addr) {
@@ -50,12 +47,9 @@ func (ks keyStorePlain) StoreKey(filename string, key *Key, auth string) error {
 	if err != nil {
 		return err
 	}
-	return writeKeyFile(filename, content)
+	return ks.storage.Write(filename, content)
 }
 
 func (ks keyStorePlain) JoinPath(filename string) string {
-	if filepath.IsAbs(filename) {
-		return filename
-	}
-	return filepath.Join(ks.keysDirPath, filename)
+	return ks.storage.Join(filename)
 }
@@ -0,0 +1,217 @@
+package keystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// pbkdf2MinIterations is the iteration count geth uses for pbkdf2 keys
+const pbkdf2MinIterations = 262144
+
+// KDFPolicy is the minimum KDF strength accepted by Audit
+type KDFPolicy struct {
+	ScryptN          int
+	PBKDF2Iterations int
+	Argon2Time       uint32
+	Argon2Memory     uint32 // KiB
+	// AllowV1 accepts the legacy version 1 key format
+	AllowV1 bool
+}
+
+// DefaultKDFPolicy accepts StandardScryptN, geth pbkdf2 and Argon2idKDF memory
+var DefaultKDFPolicy = KDFPolicy{
+	ScryptN:          StandardScryptN,
+	PBKDF2Iterations: pbkdf2MinIterations,
+	Argon2Time:       1,
+	Argon2Memory:     Argon2idKDF.Argon2Memory,
+}
+
+// KeyKDF describes how a stored key is encrypted
+type KeyKDF struct {
+	Account Account
+	Version string
+	KDF     string
+	Params  map[string]interface{} // without the salt
+	Weak    bool
+	Reason  string // why the key is weak, or the read error
+}
+
+// RotateOptions configures KeyStore.Rotate
+type RotateOptions struct {
+	Passphrase string
+	// NewPassphrase is the passphrase of the rotated keys, empty keeps Passphrase
+	NewPassphrase string
+	// KDF derives the new encryption key
+	KDF KDFConfig
+	// Filter selects the accounts to rotate, nil rotates all of them
+	Filter func(Account, KeyKDF) bool
+}
+
+// RotateFailure is a key Rotate could not migrate, left untouched
+type RotateFailure struct {
+	Account Account
+	Err     error
+}
+
+// RotateReport is the result of KeyStore.Rotate
+type RotateReport struct {
+	Migrated []Account
+	Skipped  []Account
+	Failed   []RotateFailure
+}
+
+// WeakOnly is a RotateOptions.Filter rotating the keys failing policy
+func WeakOnly(policy KDFPolicy) func(Account, KeyKDF) bool {
+	return func(_ Account, info KeyKDF) bool {
+		return checkKDF(info, policy) != ""
+	}
+}
+
+// inspectKey reads the KDF of a key json without decrypting it
+func inspectKey(keyjson []byte) (KeyKDF, error) {
+	var header struct {
+		Version interface{} `json:"version"`
+		Crypto  CryptoJSON  `json:"crypto"`
+	}
+	if err := json.Unmarshal(keyjson, &header); err != nil {
+		return KeyKDF{}, err
+	}
+	info := KeyKDF{
+		Version: fmt.Sprint(header.Version),
+		KDF:     header.Crypto.KDF,
+		Params:  make(map[string]interface{}, len(header.Crypto.KDFParams)),
+	}
+	for k, v := range header.Crypto.KDFParams {
+		if k != "salt" {
+			info.Params[k] = v
+		}
+	}
+	return info, nil
+}
+
+// checkKDF returns why info is weaker than policy, empty when it is not
+func checkKDF(info KeyKDF, policy KDFPolicy) (reason string) {
+	defer func() {
+		// ensureInt panics on malformed parameters
+		if recover() != nil {
+			reason = fmt.Sprintf("malformed %s parameters", info.KDF)
+		}
+	}()
+	if info.Version == "1" && !policy.AllowV1 {
+		return "legacy version 1 key"
+	}
+	switch info.KDF {
+	case KDFScrypt:
+		if n := ensureInt(info.Params["n"]); n < policy.ScryptN {
+			return fmt.Sprintf("scrypt n=%d below %d", n, policy.ScryptN)
+		}
+	case KDFPBKDF2:
+		if c := ensureInt(info.Params["c"]); c < policy.PBKDF2Iterations {
+			return fmt.Sprintf("pbkdf2 c=%d below %d", c, policy.PBKDF2Iterations)
+		}
+	case KDFArgon2id:
+		if t := ensureInt(info.Params["t"]); t < int(policy.Argon2Time) {
+			return fmt.Sprintf("argon2id t=%d below %d", t, policy.Argon2Time)
+		}
+		if m := ensureInt(info.Params["m"]); m < int(policy.Argon2Memory) {
+			return fmt.Sprintf("argon2id m=%dKiB below %dKiB", m, policy.Argon2Memory)
+		}
+	default:
+		return fmt.Sprintf("unknown KDF %q", info.KDF)
+	}
+	return ""
+}
+
+// KDFInfo returns how the key of account is encrypted
+func (ks *KeyStore) KDFInfo(a Account) (KeyKDF, error) {
+	a, err := ks.Find(a)
+	if err != nil {
+		return KeyKDF{}, err
+	}
+	keyjson, err := ks.keys.Read(a.URL.Path)
+	if err != nil {
+		return KeyKDF{}, err
+	}
+	info, err := inspectKey(keyjson)
+	info.Account = a
+	return info, err
+}
+
+// Audit returns the KDF of every key, flagging the ones weaker than policy.
+// Keys that cannot be read are flagged weak with the error as reason.
+func (ks *KeyStore) Audit(policy KDFPolicy) []KeyKDF {
+	accounts := ks.Accounts()
+	report := make([]KeyKDF, 0, len(accounts))
+	for _, a := range accounts {
+		info, err := ks.KDFInfo(a)
+		info.Account = a
+		if err != nil {
+			info.Weak, info.Reason = true, err.Error()
+		} else if reason := checkKDF(info, policy); reason != "" {
+			info.Weak, info.Reason = true, reason
+		}
+		report = append(report, info)
+	}
+	return report
+}
+
+// Rotate re-encrypts the keys under the new passphrase and KDF. Each new
+// key is decrypted again before it atomically replaces the old one, keys
+// that fail are reported and left untouched. New keys still use the KDF the
+// keystore was created with, see NewKeyStoreWithKDF.
+func (ks *KeyStore) Rotate(opts RotateOptions) (*RotateReport, error) {
+	if err := opts.KDF.Validate(); err != nil {
+		return nil, err
+	}
+	newPassphrase := opts.NewPassphrase
+	if newPassphrase == "" {
+		newPassphrase = opts.Passphrase
+	}
+
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	report := &RotateReport{}
+	for _, a := range ks.Accounts() {
+		if opts.Filter != nil {
+			info, err := ks.KDFInfo(a)
+			if err != nil {
+				report.Failed = append(report.Failed, RotateFailure{a, err})
+				continue
+			}
+			if !opts.Filter(a, info) {
+				report.Skipped = append(report.Skipped, a)
+				continue
+			}
+		}
+		if err := ks.rotateKey(a, opts.Passphrase, newPassphrase, opts.KDF); err != nil {
+			report.Failed = append(report.Failed, RotateFailure{a, err})
+			continue
+		}
+		report.Migrated = append(report.Migrated, a)
+	}
+	return report, nil
+}
+
+func (ks *KeyStore) rotateKey(a Account, passphrase, newPassphrase string, kdf KDFConfig) error {
+	a, key, err := ks.GetDecryptedKey(a, passphrase)
+	if err != nil {
+		return err
+	}
+	defer zeroKey(key.PrivateKey)
+
+	keyjson, err := EncryptKeyWithKDF(key, newPassphrase, kdf)
+	if err != nil {
+		return err
+	}
+	verified, err := DecryptKey(keyjson, newPassphrase)
+	if err != nil {
+		return fmt.Errorf("could not verify the rotated key: %v", err)
+	}
+	zeroKey(verified.PrivateKey)
+	if !bytes.Equal(verified.Address, a.Address) {
+		return fmt.Errorf("key content mismatch: have account %x, want %x", verified.Address, a.Address)
+	}
+	return ks.keys.Write(a.URL.Path, keyjson)
+}
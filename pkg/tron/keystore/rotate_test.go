@@ -0,0 +1,67 @@
+package keystore_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate(t *testing.T) {
+	storage := keystore.NewMemoryKeyStorage()
+	ks := keystore.NewKeyStoreWithKDF(storage, keystore.LightKDF)
+	light, err := ks.NewAccount("old")
+	require.Nil(t, err)
+	other, err := ks.NewAccount("other")
+	require.Nil(t, err)
+
+	audit := ks.Audit(keystore.DefaultKDFPolicy)
+	require.Len(t, audit, 2)
+	for _, info := range audit {
+		assert.True(t, info.Weak)
+		assert.Equal(t, keystore.KDFScrypt, info.KDF)
+		assert.NotContains(t, info.Params, "salt")
+	}
+
+	_, err = ks.Rotate(keystore.RotateOptions{Passphrase: "old", KDF: keystore.KDFConfig{Name: "md5"}})
+	assert.NotNil(t, err)
+
+	report, err := ks.Rotate(keystore.RotateOptions{
+		Passphrase:    "old",
+		NewPassphrase: "new",
+		KDF:           keystore.Argon2idKDF,
+		Filter:        keystore.WeakOnly(keystore.DefaultKDFPolicy),
+	})
+	require.Nil(t, err)
+	require.Len(t, report.Migrated, 1)
+	assert.Equal(t, light.Address, report.Migrated[0].Address)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, other.Address, report.Failed[0].Account.Address)
+
+	_, _, err = ks.GetDecryptedKey(light, "old")
+	assert.NotNil(t, err)
+	_, key, err := ks.GetDecryptedKey(light, "new")
+	require.Nil(t, err)
+	assert.Equal(t, light.Address, key.Address)
+	_, _, err = ks.GetDecryptedKey(other, "other")
+	require.Nil(t, err)
+
+	info, err := ks.KDFInfo(light)
+	require.Nil(t, err)
+	assert.Equal(t, keystore.KDFArgon2id, info.KDF)
+	for _, info := range ks.Audit(keystore.DefaultKDFPolicy) {
+		assert.Equal(t, info.Account.Address.String() == other.Address.String(), info.Weak)
+	}
+
+	// a second pass skips the upgraded key
+	report, err = ks.Rotate(keystore.RotateOptions{
+		Passphrase: "other",
+		KDF:        keystore.Argon2idKDF,
+		Filter:     keystore.WeakOnly(keystore.DefaultKDFPolicy),
+	})
+	require.Nil(t, err)
+	assert.Len(t, report.Migrated, 1)
+	assert.Len(t, report.Skipped, 1)
+	assert.Empty(t, report.Failed)
+}
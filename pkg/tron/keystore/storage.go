@@ -0,0 +1,165 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyEntry is a key blob listed by a KeyStorage
+type KeyEntry struct {
+	Name    string // as used in account URLs and passed back to Read
+	ModTime time.Time
+}
+
+// KeyStorage persists the key blobs of a KeyStore. Names are the ones
+// returned by List or Join; the content is the (encrypted) key JSON. Names
+// starting with a dot hold metadata and are not listed.
+type KeyStorage interface {
+	// List returns all the stored keys.
+	List() ([]KeyEntry, error)
+	// Read returns the content stored under name.
+	Read(name string) ([]byte, error)
+	// Write atomically creates or replaces the content stored under name.
+	Write(name string, content []byte) error
+	// Delete removes the content stored under name.
+	Delete(name string) error
+	// Join returns the name a new key file gets in the storage.
+	Join(filename string) string
+}
+
+// FileKeyStorage stores each key as a file in a directory. The directory is
+// created on the first write, or explicitly with EnsureDir.
+type FileKeyStorage struct {
+	dir string
+}
+
+// NewFileKeyStorage returns a storage over the directory dir
+func NewFileKeyStorage(dir string) *FileKeyStorage {
+	dir, _ = filepath.Abs(dir)
+	return &FileKeyStorage{dir: dir}
+}
+
+// Dir returns the key directory
+func (fs *FileKeyStorage) Dir() string {
+	return fs.dir
+}
+
+// EnsureDir creates the key directory if needed
+func (fs *FileKeyStorage) EnsureDir() error {
+	return os.MkdirAll(fs.dir, 0700)
+}
+
+// List implements KeyStorage, skipping hidden files, backups and folders.
+// A missing directory holds no keys.
+func (fs *FileKeyStorage) List() ([]KeyEntry, error) {
+	files, err := ioutil.ReadDir(fs.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entries := make([]KeyEntry, 0, len(files))
+	for _, fi := range files {
+		if nonKeyFile(fi) {
+			continue
+		}
+		entries = append(entries, KeyEntry{Name: filepath.Join(fs.dir, fi.Name()), ModTime: fi.ModTime()})
+	}
+	return entries, nil
+}
+
+// Read implements KeyStorage
+func (fs *FileKeyStorage) Read(name string) ([]byte, error) {
+	return ioutil.ReadFile(fs.Join(name))
+}
+
+// Write implements KeyStorage using a temporary file renamed into place
+func (fs *FileKeyStorage) Write(name string, content []byte) error {
+	return writeKeyFile(fs.Join(name), content)
+}
+
+// Delete implements KeyStorage
+func (fs *FileKeyStorage) Delete(name string) error {
+	return os.Remove(fs.Join(name))
+}
+
+// Join implements KeyStorage, joining filename with the key directory
+// unless it is already absolute
+func (fs *FileKeyStorage) Join(filename string) string {
+	if filepath.IsAbs(filename) {
+		return filename
+	}
+	return filepath.Join(fs.dir, filename)
+}
+
+// MemoryKeyStorage keeps keys in memory, for tests and ephemeral services
+type MemoryKeyStorage struct {
+	mu   sync.RWMutex
+	keys map[string]memoryKey
+}
+
+type memoryKey struct {
+	content []byte
+	modTime time.Time
+}
+
+// NewMemoryKeyStorage returns an empty in-memory storage
+func NewMemoryKeyStorage() *MemoryKeyStorage {
+	return &MemoryKeyStorage{keys: make(map[string]memoryKey)}
+}
+
+// List implements KeyStorage
+func (ms *MemoryKeyStorage) List() ([]KeyEntry, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	entries := make([]KeyEntry, 0, len(ms.keys))
+	for name, key := range ms.keys {
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		entries = append(entries, KeyEntry{Name: name, ModTime: key.modTime})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// Read implements KeyStorage
+func (ms *MemoryKeyStorage) Read(name string) ([]byte, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	key, ok := ms.keys[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return append([]byte{}, key.content...), nil
+}
+
+// Write implements KeyStorage
+func (ms *MemoryKeyStorage) Write(name string, content []byte) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.keys[name] = memoryKey{content: append([]byte{}, content...), modTime: time.Now()}
+	return nil
+}
+
+// Delete implements KeyStorage
+func (ms *MemoryKeyStorage) Delete(name string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, ok := ms.keys[name]; !ok {
+		return os.ErrNotExist
+	}
+	delete(ms.keys, name)
+	return nil
+}
+
+// Join implements KeyStorage
+func (ms *MemoryKeyStorage) Join(filename string) string {
+	return filename
+}
@@ -0,0 +1,164 @@
+package keystore
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+)
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLKeyStorage stores keys as rows of a database/sql table, keyed by
+// (namespace, name). Several keystores can share a table using different
+// namespaces.
+type SQLKeyStorage struct {
+	db        *sql.DB
+	table     string
+	namespace string
+	// Placeholder returns the bind variable for the n-th (1 based) argument,
+	// "?" by default, set to DollarPlaceholder for PostgreSQL
+	Placeholder func(n int) string
+}
+
+// DollarPlaceholder returns the PostgreSQL bind variable $n
+func DollarPlaceholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// NewSQLKeyStorage returns a storage over table for namespace. The table is
+// created with CreateTable.
+func NewSQLKeyStorage(db *sql.DB, table, namespace string) (*SQLKeyStorage, error) {
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	return &SQLKeyStorage{
+		db:          db,
+		table:       table,
+		namespace:   namespace,
+		Placeholder: func(int) string { return "?" },
+	}, nil
+}
+
+// SQLNamespaces returns the namespaces present in table
+func SQLNamespaces(db *sql.DB, table string) ([]string, error) {
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	rows, err := db.Query(fmt.Sprintf("SELECT DISTINCT namespace FROM %s ORDER BY namespace", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	namespaces := []string{}
+	for rows.Next() {
+		var namespace string
+		if err := rows.Scan(&namespace); err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, rows.Err()
+}
+
+// CreateTable creates the key table if it does not exist
+func (s *SQLKeyStorage) CreateTable() error {
+	_, err := s.db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	namespace VARCHAR(255) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	content TEXT NOT NULL,
+	modified BIGINT NOT NULL,
+	PRIMARY KEY (namespace, name)
+)`, s.table))
+	return err
+}
+
+// Namespace returns the namespace of the storage
+func (s *SQLKeyStorage) Namespace() string {
+	return s.namespace
+}
+
+// List implements KeyStorage
+func (s *SQLKeyStorage) List() ([]KeyEntry, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT name, modified FROM %s WHERE namespace = %s AND name NOT LIKE '.%%' ORDER BY name",
+		s.table, s.Placeholder(1)), s.namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := []KeyEntry{}
+	for rows.Next() {
+		var (
+			name     string
+			modified int64
+		)
+		if err := rows.Scan(&name, &modified); err != nil {
+			return nil, err
+		}
+		entries = append(entries, KeyEntry{Name: name, ModTime: time.Unix(0, modified)})
+	}
+	return entries, rows.Err()
+}
+
+// Read implements KeyStorage
+func (s *SQLKeyStorage) Read(name string) ([]byte, error) {
+	var content string
+	err := s.db.QueryRow(fmt.Sprintf("SELECT content FROM %s WHERE namespace = %s AND name = %s",
+		s.table, s.Placeholder(1), s.Placeholder(2)), s.namespace, name).Scan(&content)
+	if err == sql.ErrNoRows {
+		return nil, os.ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// Write implements KeyStorage, replacing the row in a transaction
+func (s *SQLKeyStorage) Write(name string, content []byte) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE namespace = %s AND name = %s",
+		s.table, s.Placeholder(1), s.Placeholder(2)), s.namespace, name); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (namespace, name, content, modified) VALUES (%s, %s, %s, %s)",
+		s.table, s.Placeholder(1), s.Placeholder(2), s.Placeholder(3), s.Placeholder(4)),
+		s.namespace, name, string(content), time.Now().UnixNano()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Delete implements KeyStorage
+func (s *SQLKeyStorage) Delete(name string) error {
+	res, err := s.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE namespace = %s AND name = %s",
+		s.table, s.Placeholder(1), s.Placeholder(2)), s.namespace, name)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return os.ErrNotExist
+	}
+	return nil
+}
+
+// MoveTo moves every row of the storage to namespace
+func (s *SQLKeyStorage) MoveTo(namespace string) error {
+	if _, err := s.db.Exec(fmt.Sprintf("UPDATE %s SET namespace = %s WHERE namespace = %s",
+		s.table, s.Placeholder(1), s.Placeholder(2)), namespace, s.namespace); err != nil {
+		return err
+	}
+	s.namespace = namespace
+	return nil
+}
+
+// Join implements KeyStorage
+func (s *SQLKeyStorage) Join(filename string) string {
+	return filename
+}
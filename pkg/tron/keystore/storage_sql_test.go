@@ -0,0 +1,44 @@
+//go:build sqlite
+// +build sqlite
+
+// SQL storage tests need the cgo sqlite3 driver: go test -tags sqlite
+
+package keystore_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSQLKeyStorage(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "keys.db"))
+	require.Nil(t, err)
+	defer db.Close()
+
+	_, err = keystore.NewSQLKeyStorage(db, "keys; DROP TABLE x", "main")
+	assert.NotNil(t, err)
+
+	storage, err := keystore.NewSQLKeyStorage(db, "tron_keys", "main")
+	require.Nil(t, err)
+	require.Nil(t, storage.CreateTable())
+	testKeyStorage(t, storage)
+
+	other, err := keystore.NewSQLKeyStorage(db, "tron_keys", "other")
+	require.Nil(t, err)
+	require.Nil(t, other.Write("key", []byte(`{}`)))
+	require.Nil(t, other.Write("key", []byte(`{"replaced":true}`)))
+	content, err := other.Read("key")
+	require.Nil(t, err)
+	assert.Equal(t, `{"replaced":true}`, string(content))
+
+	namespaces, err := keystore.SQLNamespaces(db, "tron_keys")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"other"}, namespaces)
+}
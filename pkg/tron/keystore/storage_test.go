@@ -0,0 +1,71 @@
+package keystore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+	"github.com/EntySquare/chain-util/pkg/tron/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeyStorage(t *testing.T, storage keystore.KeyStorage) {
+	ks := keystore.NewKeyStoreWithStorage(storage, keystore.LightScryptN, keystore.LightScryptP)
+	assert.Empty(t, ks.Accounts())
+
+	account, err := ks.NewAccount("secret")
+	require.Nil(t, err)
+	assert.True(t, ks.HasAddress(account.Address))
+
+	entries, err := storage.List()
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, account.URL.Path, entries[0].Name)
+
+	// a fresh keystore over the same storage finds the key
+	reopened := keystore.NewKeyStoreWithStorage(storage, keystore.LightScryptN, keystore.LightScryptP)
+	require.Len(t, reopened.Accounts(), 1)
+	assert.Equal(t, account.Address, reopened.Accounts()[0].Address)
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1600000000000}}
+	_, err = reopened.SignTxWithPassphrase(account, "wrong", tx)
+	assert.NotNil(t, err)
+	_, err = reopened.SignTxWithPassphrase(account, "secret", tx)
+	require.Nil(t, err)
+
+	require.Nil(t, reopened.Update(account, "secret", "changed"))
+	_, _, err = reopened.GetDecryptedKey(account, "changed")
+	require.Nil(t, err)
+
+	require.Nil(t, reopened.Delete(account, "changed"))
+	assert.False(t, reopened.HasAddress(account.Address))
+	entries, err = storage.List()
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+	_, err = storage.Read(account.URL.Path)
+	assert.NotNil(t, err)
+}
+
+func TestFileKeyStorage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	storage := keystore.NewFileKeyStorage(dir)
+	// a missing directory is empty and only created on write
+	entries, err := storage.List()
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+	testKeyStorage(t, storage)
+
+	// a failed write leaves no temporary file behind
+	require.Nil(t, os.MkdirAll(filepath.Join(dir, "taken"), 0700))
+	assert.NotNil(t, storage.Write("taken", []byte(`{}`)))
+	files, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "taken", files[0].Name())
+}
+
+func TestMemoryKeyStorage(t *testing.T) {
+	testKeyStorage(t, keystore.NewMemoryKeyStorage())
+}
@@ -0,0 +1,278 @@
+package store
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/EntySquare/chain-util/pkg/tron"
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+)
+
+// labelsName is the metadata entry holding the labels of an alias, hidden
+// from the key listing by the leading dot
+const labelsName = ".labels.json"
+
+// AccountInfo one account stored under an alias
+type AccountInfo struct {
+	Name    string   `json:"name"`    // alias
+	Address string   `json:"address"` // base58
+	URL     string   `json:"url"`
+	Labels  []string `json:"labels,omitempty"`
+}
+
+// HasLabel returns true if the account carries label
+func (a AccountInfo) HasLabel(label string) bool {
+	for _, l := range a.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// readLabels returns the labels of alias by base58 address
+func readLabels(storage keystore.KeyStorage) (map[string][]string, error) {
+	labels := make(map[string][]string)
+	content, err := storage.Read(storage.Join(labelsName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return labels, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &labels); err != nil {
+		return nil, fmt.Errorf("invalid labels: %v", err)
+	}
+	return labels, nil
+}
+
+func writeLabels(storage keystore.KeyStorage, labels map[string][]string) error {
+	content, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	return storage.Write(storage.Join(labelsName), content)
+}
+
+// NamedAccounts returns the accounts stored under name
+func NamedAccounts(name string) ([]AccountInfo, error) {
+	storage := CurrentBackend().Storage(name)
+	labels, err := readLabels(storage)
+	if err != nil {
+		return nil, fmt.Errorf("account %s: %v", name, err)
+	}
+	ks := keystore.NewKeyStoreWithStorage(storage, keystore.StandardScryptN, keystore.StandardScryptP)
+	accounts := ks.Accounts()
+	infos := make([]AccountInfo, 0, len(accounts))
+	for _, account := range accounts {
+		address := account.Address.String()
+		infos = append(infos, AccountInfo{
+			Name:    name,
+			Address: address,
+			URL:     account.URL.String(),
+			Labels:  labels[address],
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Address < infos[j].Address })
+	return infos, nil
+}
+
+// ListAccounts returns the accounts of every alias
+func ListAccounts() ([]AccountInfo, error) {
+	names, err := CurrentBackend().Aliases()
+	if err != nil {
+		return nil, err
+	}
+	infos := []AccountInfo{}
+	for _, name := range names {
+		accounts, err := NamedAccounts(name)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, accounts...)
+	}
+	return infos, nil
+}
+
+// AddressesFromAccountName returns every address stored under name
+func AddressesFromAccountName(name string) ([]string, error) {
+	accounts, err := NamedAccounts(name)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, len(accounts))
+	for i, account := range accounts {
+		addresses[i] = account.Address
+	}
+	return addresses, nil
+}
+
+// FindByAddress returns the accounts holding addr, one per alias storing it
+func FindByAddress(addr string) ([]AccountInfo, error) {
+	if _, err := tron.Base58ToAddress(addr); err != nil {
+		return nil, fmt.Errorf("address not valid: %s", addr)
+	}
+	all, err := ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+	found := []AccountInfo{}
+	for _, account := range all {
+		if account.Address == addr {
+			found = append(found, account)
+		}
+	}
+	return found, nil
+}
+
+// FindByLabel returns the accounts carrying label
+func FindByLabel(label string) ([]AccountInfo, error) {
+	all, err := ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+	found := []AccountInfo{}
+	for _, account := range all {
+		if account.HasLabel(label) {
+			found = append(found, account)
+		}
+	}
+	return found, nil
+}
+
+// NewAccountInName generate a new key under name, creating the alias if needed
+func NewAccountInName(name, passphrase string) (AccountInfo, error) {
+	account, err := FromAccountName(name).NewAccount(passphrase)
+	if err != nil {
+		return AccountInfo{}, err
+	}
+	return AccountInfo{Name: name, Address: account.Address.String(), URL: account.URL.String()}, nil
+}
+
+// ImportToName store key under name, creating the alias if needed
+func ImportToName(name string, key *ecdsa.PrivateKey, passphrase string) (AccountInfo, error) {
+	account, err := FromAccountName(name).ImportECDSA(key, passphrase)
+	if err != nil {
+		return AccountInfo{}, err
+	}
+	return AccountInfo{Name: name, Address: account.Address.String(), URL: account.URL.String()}, nil
+}
+
+// RemoveFromName delete one account of name, checking its passphrase
+func RemoveFromName(name, addr, passphrase string) error {
+	address, err := tron.Base58ToAddress(addr)
+	if err != nil {
+		return fmt.Errorf("address not valid: %s", addr)
+	}
+	ks := FromAccountName(name)
+	if err := ks.Delete(keystore.Account{Address: address}, passphrase); err != nil {
+		return err
+	}
+	return UpdateLabels(name, addr, func([]string) []string { return nil })
+}
+
+// RenameAccountName move every account of name to newName
+func RenameAccountName(name, newName string) error {
+	if strings.TrimSpace(newName) == "" || strings.ContainsAny(newName, `/\`) {
+		return fmt.Errorf("invalid account name %q", newName)
+	}
+	if !DoesNamedAccountExist(name) {
+		return fmt.Errorf("account %s doesn't exist", name)
+	}
+	if DoesNamedAccountExist(newName) {
+		return fmt.Errorf("account %s already exists", newName)
+	}
+	return CurrentBackend().Rename(name, newName)
+}
+
+// UpdateLabels replace the labels of addr under name with the result of update
+func UpdateLabels(name, addr string, update func(labels []string) []string) error {
+	storage := CurrentBackend().Storage(name)
+	labels, err := readLabels(storage)
+	if err != nil {
+		return fmt.Errorf("account %s: %v", name, err)
+	}
+	updated := update(append([]string{}, labels[addr]...))
+	if len(updated) == 0 {
+		if _, ok := labels[addr]; !ok {
+			return nil
+		}
+		delete(labels, addr)
+	} else {
+		labels[addr] = updated
+	}
+	return writeLabels(storage, labels)
+}
+
+// SetLabels replace the labels of addr under name
+func SetLabels(name, addr string, labels ...string) error {
+	if err := requireNamedAddress(name, addr); err != nil {
+		return err
+	}
+	return UpdateLabels(name, addr, func([]string) []string { return dedupLabels(labels) })
+}
+
+// AddLabels add labels to addr under name
+func AddLabels(name, addr string, labels ...string) error {
+	if err := requireNamedAddress(name, addr); err != nil {
+		return err
+	}
+	return UpdateLabels(name, addr, func(current []string) []string {
+		return dedupLabels(append(current, labels...))
+	})
+}
+
+// RemoveLabels remove labels from addr under name
+func RemoveLabels(name, addr string, labels ...string) error {
+	if err := requireNamedAddress(name, addr); err != nil {
+		return err
+	}
+	return UpdateLabels(name, addr, func(current []string) []string {
+		kept := current[:0]
+		for _, l := range current {
+			removed := false
+			for _, r := range labels {
+				if l == r {
+					removed = true
+					break
+				}
+			}
+			if !removed {
+				kept = append(kept, l)
+			}
+		}
+		return kept
+	})
+}
+
+func requireNamedAddress(name, addr string) error {
+	addresses, err := AddressesFromAccountName(name)
+	if err != nil {
+		return err
+	}
+	for _, a := range addresses {
+		if a == addr {
+			return nil
+		}
+	}
+	return fmt.Errorf("account %s doesn't hold %s", name, addr)
+}
+
+func dedupLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	out := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = strings.TrimSpace(l)
+		if l == "" || seen[l] {
+			continue
+		}
+		seen[l] = true
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
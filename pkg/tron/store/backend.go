@@ -0,0 +1,253 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+)
+
+// Backend provides the key storage behind each account alias
+type Backend interface {
+	// Aliases returns the account alias names
+	Aliases() ([]string, error)
+	// Storage returns the key storage of alias
+	Storage(alias string) keystore.KeyStorage
+	// Remove deletes alias and all its keys
+	Remove(alias string) error
+	// Rename moves the keys of alias to a new, unused, alias
+	Rename(alias, newAlias string) error
+}
+
+var (
+	backendMu sync.RWMutex
+	backend   Backend = dirBackend{}
+)
+
+// SetBackend replace the storage of all the account aliases, nil restores the
+// directory under DefaultLocation
+func SetBackend(b Backend) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	if b == nil {
+		b = dirBackend{}
+	}
+	backend = b
+}
+
+// CurrentBackend returns the backend in use
+func CurrentBackend() Backend {
+	backendMu.RLock()
+	defer backendMu.RUnlock()
+	return backend
+}
+
+// DirBackend keeps one sub directory of keys per alias
+type DirBackend struct {
+	Root string
+}
+
+// NewDirBackend returns a backend rooted at dir
+func NewDirBackend(dir string) *DirBackend {
+	return &DirBackend{Root: dir}
+}
+
+// EnsureRoot creates the root directory if needed
+func (d *DirBackend) EnsureRoot() error {
+	return os.MkdirAll(d.Root, 0700)
+}
+
+// Aliases implements Backend, a missing root holds no alias
+func (d *DirBackend) Aliases() ([]string, error) {
+	files, err := ioutil.ReadDir(d.Root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	aliases := []string{}
+	for _, node := range files {
+		if node.IsDir() {
+			aliases = append(aliases, path.Base(node.Name()))
+		}
+	}
+	return aliases, nil
+}
+
+// Storage implements Backend
+func (d *DirBackend) Storage(alias string) keystore.KeyStorage {
+	return keystore.NewFileKeyStorage(path.Join(d.Root, alias))
+}
+
+// Remove implements Backend
+func (d *DirBackend) Remove(alias string) error {
+	return os.RemoveAll(path.Join(d.Root, alias))
+}
+
+// Rename implements Backend
+func (d *DirBackend) Rename(alias, newAlias string) error {
+	if _, err := os.Stat(path.Join(d.Root, newAlias)); err == nil {
+		return fmt.Errorf("account %s already exists", newAlias)
+	}
+	return os.Rename(path.Join(d.Root, alias), path.Join(d.Root, newAlias))
+}
+
+// dirBackend is the DirBackend following DefaultLocation
+type dirBackend struct{}
+
+func (dirBackend) current() *DirBackend         { return NewDirBackend(DefaultLocation()) }
+func (b dirBackend) Aliases() ([]string, error) { return b.current().Aliases() }
+func (b dirBackend) Remove(alias string) error  { return b.current().Remove(alias) }
+func (b dirBackend) Rename(alias, newAlias string) error {
+	return b.current().Rename(alias, newAlias)
+}
+func (b dirBackend) Storage(alias string) keystore.KeyStorage {
+	return b.current().Storage(alias)
+}
+
+// MemoryBackend keeps every alias in memory
+type MemoryBackend struct {
+	mu      sync.Mutex
+	aliases map[string]*keystore.MemoryKeyStorage
+}
+
+// NewMemoryBackend returns an empty in-memory backend
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{aliases: make(map[string]*keystore.MemoryKeyStorage)}
+}
+
+// Aliases implements Backend, listing the aliases holding keys
+func (m *MemoryBackend) Aliases() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	aliases := []string{}
+	for alias, storage := range m.aliases {
+		if keys, _ := storage.List(); len(keys) > 0 {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases, nil
+}
+
+// Storage implements Backend
+func (m *MemoryBackend) Storage(alias string) keystore.KeyStorage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	storage, ok := m.aliases[alias]
+	if !ok {
+		storage = keystore.NewMemoryKeyStorage()
+		m.aliases[alias] = storage
+	}
+	return storage
+}
+
+// Remove implements Backend
+func (m *MemoryBackend) Remove(alias string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.aliases, alias)
+	return nil
+}
+
+// Rename implements Backend
+func (m *MemoryBackend) Rename(alias, newAlias string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	storage, ok := m.aliases[alias]
+	if !ok {
+		return fmt.Errorf("account %s doesn't exist", alias)
+	}
+	if _, ok := m.aliases[newAlias]; ok {
+		return fmt.Errorf("account %s already exists", newAlias)
+	}
+	delete(m.aliases, alias)
+	m.aliases[newAlias] = storage
+	return nil
+}
+
+// SQLBackend stores all the aliases in one table, using the alias as namespace
+type SQLBackend struct {
+	db    *sql.DB
+	table string
+	// Placeholder is passed to every keystore.SQLKeyStorage, nil keeps "?"
+	Placeholder func(n int) string
+}
+
+// NewSQLBackend returns a backend over table, creating it if needed
+func NewSQLBackend(db *sql.DB, table string) (*SQLBackend, error) {
+	b := &SQLBackend{db: db, table: table}
+	storage, err := b.storage("")
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.CreateTable(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *SQLBackend) storage(alias string) (*keystore.SQLKeyStorage, error) {
+	storage, err := keystore.NewSQLKeyStorage(b.db, b.table, alias)
+	if err != nil {
+		return nil, err
+	}
+	if b.Placeholder != nil {
+		storage.Placeholder = b.Placeholder
+	}
+	return storage, nil
+}
+
+// Aliases implements Backend
+func (b *SQLBackend) Aliases() ([]string, error) {
+	return keystore.SQLNamespaces(b.db, b.table)
+}
+
+// Storage implements Backend
+func (b *SQLBackend) Storage(alias string) keystore.KeyStorage {
+	// the table name was validated by NewSQLBackend
+	storage, _ := b.storage(alias)
+	return storage
+}
+
+// Remove implements Backend
+func (b *SQLBackend) Remove(alias string) error {
+	storage, err := b.storage(alias)
+	if err != nil {
+		return err
+	}
+	keys, err := storage.List()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := storage.Delete(key.Name); err != nil {
+			return fmt.Errorf("could not remove %s of %s: %v", key.Name, alias, err)
+		}
+	}
+	return nil
+}
+
+// Rename implements Backend
+func (b *SQLBackend) Rename(alias, newAlias string) error {
+	aliases, err := b.Aliases()
+	if err != nil {
+		return err
+	}
+	for _, existing := range aliases {
+		if existing == newAlias {
+			return fmt.Errorf("account %s already exists", newAlias)
+		}
+	}
+	storage, err := b.storage(alias)
+	if err != nil {
+		return err
+	}
+	return storage.MoveTo(newAlias)
+}
@@ -0,0 +1,27 @@
+//go:build sqlite
+// +build sqlite
+
+// SQL backend tests need the cgo sqlite3 driver: go test -tags sqlite
+
+package store_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron/store"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSQLBackend(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "keys.db"))
+	require.Nil(t, err)
+	defer db.Close()
+
+	backend, err := store.NewSQLBackend(db, "tron_keys")
+	require.Nil(t, err)
+	testBackend(t, backend)
+}
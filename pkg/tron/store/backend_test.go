@@ -0,0 +1,93 @@
+package store_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/EntySquare/chain-util/pkg/tron/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBackend(t *testing.T, backend store.Backend) {
+	store.SetBackend(backend)
+	defer store.SetBackend(nil)
+
+	assert.False(t, store.DoesNamedAccountExist("alice"))
+	account, err := store.FromAccountName("alice").NewAccount("secret")
+	require.Nil(t, err)
+	assert.True(t, store.DoesNamedAccountExist("alice"))
+	accounts, err := store.LocalAccounts()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, accounts)
+
+	address, err := store.AddressFromAccountName("alice")
+	require.Nil(t, err)
+	assert.Equal(t, account.Address.String(), address)
+	assert.NotNil(t, store.FromAddress(address))
+
+	_, _, err = store.UnlockedKeystore(address, "secret")
+	require.Nil(t, err)
+
+	require.Nil(t, store.RenameAccountName("alice", "bob"))
+	assert.False(t, store.DoesNamedAccountExist("alice"))
+	address, err = store.AddressFromAccountName("bob")
+	require.Nil(t, err)
+	assert.Equal(t, account.Address.String(), address)
+
+	require.Nil(t, store.RemoveAccountName("bob"))
+	assert.False(t, store.DoesNamedAccountExist("bob"))
+}
+
+func TestDirBackend(t *testing.T) {
+	testBackend(t, store.NewDirBackend(filepath.Join(t.TempDir(), "aliases")))
+}
+
+func TestMemoryBackend(t *testing.T) {
+	testBackend(t, store.NewMemoryBackend())
+}
+
+func TestAliasManagement(t *testing.T) {
+	store.SetBackend(store.NewMemoryBackend())
+	defer store.SetBackend(nil)
+
+	first, err := store.NewAccountInName("treasury", "secret")
+	require.Nil(t, err)
+	second, err := store.NewAccountInName("treasury", "secret")
+	require.Nil(t, err)
+
+	addresses, err := store.AddressesFromAccountName("treasury")
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []string{first.Address, second.Address}, addresses)
+	_, err = store.AddressFromAccountName("treasury")
+	assert.NotNil(t, err)
+
+	require.Nil(t, store.AddLabels("treasury", first.Address, "hot", "payout", "hot"))
+	assert.NotNil(t, store.AddLabels("treasury", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "hot"))
+	found, err := store.FindByLabel("hot")
+	require.Nil(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, first.Address, found[0].Address)
+	assert.Equal(t, []string{"hot", "payout"}, found[0].Labels)
+
+	require.Nil(t, store.RenameAccountName("treasury", "vault"))
+	assert.NotNil(t, store.RenameAccountName("treasury", "vault"))
+	found, err = store.FindByAddress(second.Address)
+	require.Nil(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "vault", found[0].Name)
+
+	require.Nil(t, store.RemoveLabels("vault", first.Address, "hot"))
+	assert.NotNil(t, store.RemoveLabels("vault", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "hot"))
+	found, err = store.FindByLabel("payout")
+	require.Nil(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, []string{"payout"}, found[0].Labels)
+
+	require.Nil(t, store.RemoveFromName("vault", first.Address, "secret"))
+	all, err := store.ListAccounts()
+	require.Nil(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, second.Address, all[0].Address)
+	assert.Empty(t, all[0].Labels)
+}
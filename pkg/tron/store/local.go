@@ -5,8 +5,6 @@ import (
 	"github.com/EntySquare/chain-util/pkg/tron"
 	"github.com/EntySquare/chain-util/pkg/tron/common"
 	"github.com/EntySquare/chain-util/pkg/tron/keystore"
-	"io/ioutil"
-	"os"
 	"path"
 
 	"github.com/pkg/errors"
@@ -14,30 +12,9 @@ import (
 	homedir "github.com/mitchellh/go-homedir"
 )
 
-func init() {
-	uDir, _ := homedir.Dir()
-	tronCTLDir := path.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName)
-	if _, err := os.Stat(tronCTLDir); os.IsNotExist(err) {
-		os.MkdirAll(tronCTLDir, 0700)
-	}
-}
-
 // LocalAccounts returns a slice of local account alias names
-func LocalAccounts() []string {
-	uDir, _ := homedir.Dir()
-	files, _ := ioutil.ReadDir(path.Join(
-		uDir,
-		common.DefaultConfigDirName,
-		common.DefaultConfigAccountAliasesDirName,
-	))
-	accounts := []string{}
-
-	for _, node := range files {
-		if node.IsDir() {
-			accounts = append(accounts, path.Base(node.Name()))
-		}
-	}
-	return accounts
+func LocalAccounts() ([]string, error) {
+	return CurrentBackend().Aliases()
 }
 
 var (
@@ -46,22 +23,28 @@ var (
 	ErrNoUnlockBadPassphrase = fmt.Errorf("could not unlock account with passphrase, perhaps need different phrase")
 )
 
-// DescribeLocalAccounts will display all the account alias name and their corresponding one address
-func DescribeLocalAccounts() {
+// DescribeLocalAccounts will display all the account alias name and their addresses,
+// see ListAccounts for the data
+func DescribeLocalAccounts() error {
+	accounts, err := ListAccounts()
+	if err != nil {
+		return err
+	}
 	fmt.Println(describe)
-	for _, name := range LocalAccounts() {
-		ks := FromAccountName(name)
-		allAccounts := ks.Accounts()
-		for _, account := range allAccounts {
-			fmt.Printf("%-48s\t%s\n", name, account.Address)
-		}
+	for _, account := range accounts {
+		fmt.Printf("%-48s\t%s\n", account.Name, account.Address)
 	}
+	return nil
 }
 
 // DoesNamedAccountExist return true if the given string name is an alias account already define,
-// and return false otherwise
+// and return false otherwise, including when the aliases cannot be listed
 func DoesNamedAccountExist(name string) bool {
-	for _, account := range LocalAccounts() {
+	accounts, err := LocalAccounts()
+	if err != nil {
+		return false
+	}
+	for _, account := range accounts {
 		if account == name {
 			return true
 		}
@@ -69,19 +52,31 @@ func DoesNamedAccountExist(name string) bool {
 	return false
 }
 
-// AddressFromAccountName Returns address for account name if exists
+// AddressFromAccountName Returns address for account name if exists, failing when
+// the name holds several accounts, see AddressesFromAccountName
 func AddressFromAccountName(name string) (string, error) {
-	ks := FromAccountName(name)
-	// FIXME: Assume 1 account per keystore for now
-	for _, account := range ks.Accounts() {
-		return account.Address.String(), nil
+	addresses, err := AddressesFromAccountName(name)
+	if err != nil {
+		return "", err
+	}
+	switch len(addresses) {
+	case 0:
+		return "", fmt.Errorf("keystore not found")
+	case 1:
+		return addresses[0], nil
+	default:
+		return "", fmt.Errorf("account %s holds %d addresses", name, len(addresses))
 	}
-	return "", fmt.Errorf("keystore not found")
 }
 
 // FromAddress will return nil if the Base58 string is not found in the imported accounts
+// or when the aliases cannot be listed
 func FromAddress(addr string) *keystore.KeyStore {
-	for _, name := range LocalAccounts() {
+	accounts, err := LocalAccounts()
+	if err != nil {
+		return nil
+	}
+	for _, name := range accounts {
 		ks := FromAccountName(name)
 		allAccounts := ks.Accounts()
 		for _, account := range allAccounts {
@@ -95,9 +90,12 @@ func FromAddress(addr string) *keystore.KeyStore {
 
 // FromAccountName get account from name
 func FromAccountName(name string) *keystore.KeyStore {
-	uDir, _ := homedir.Dir()
-	p := path.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName, name)
-	return keystore.ForPath(p)
+	return keystore.NewKeyStoreWithStorage(CurrentBackend().Storage(name), keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// RemoveAccountName delete the alias and all its keys
+func RemoveAccountName(name string) error {
+	return CurrentBackend().Remove(name)
 }
 
 // DefaultLocation get deafault location
@@ -106,14 +104,14 @@ func DefaultLocation() string {
 	return path.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName)
 }
 
-// SetDefaultLocation set deafault location
+// SetDefaultLocation set deafault location, see EnsureDefaultLocation
 func SetDefaultLocation(directory string) {
 	common.DefaultConfigDirName = directory
-	uDir, _ := homedir.Dir()
-	tronCTLDir := path.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName)
-	if _, err := os.Stat(tronCTLDir); os.IsNotExist(err) {
-		os.MkdirAll(tronCTLDir, 0700)
-	}
+}
+
+// EnsureDefaultLocation create the default location if needed
+func EnsureDefaultLocation() error {
+	return NewDirBackend(DefaultLocation()).EnsureRoot()
 }
 
 // UnlockedKeystore return keystore unlocked
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"github.com/EntySquare/chain-util/pkg/tron/keystore"
+)
+
+// RotateAccounts rotate the keys of every alias, see keystore.KeyStore.Rotate
+func RotateAccounts(opts keystore.RotateOptions) (map[string]*keystore.RotateReport, error) {
+	names, err := CurrentBackend().Aliases()
+	if err != nil {
+		return nil, err
+	}
+	reports := make(map[string]*keystore.RotateReport, len(names))
+	for _, name := range names {
+		report, err := FromAccountName(name).Rotate(opts)
+		if err != nil {
+			return reports, err
+		}
+		reports[name] = report
+	}
+	return reports, nil
+}
+
+// AuditAccounts returns the KDF of the keys of every alias by alias
+func AuditAccounts(policy keystore.KDFPolicy) (map[string][]keystore.KeyKDF, error) {
+	names, err := CurrentBackend().Aliases()
+	if err != nil {
+		return nil, err
+	}
+	audits := make(map[string][]keystore.KeyKDF, len(names))
+	for _, name := range names {
+		audits[name] = FromAccountName(name).Audit(policy)
+	}
+	return audits, nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tyler-smith/go-bip39"
 	"sync"
+
+	"github.com/EntySquare/chain-util/pkg/secret"
 )
 
 type Wallet struct {
@@ -170,6 +172,21 @@ func (w *Wallet) derivePublicKey(path accounts.DerivationPath) (*ecdsa.PublicKey
 
 // DerivePrivateKey derives the private key of the derivation path.
 func (w *Wallet) derivePrivateKey(path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, err := w.deriveKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey.ToECDSA(), nil
+}
+
+// deriveKey derives the extended key of the derivation path.
+func (w *Wallet) deriveKey(path accounts.DerivationPath) (*hdkeychain.ExtendedKey, error) {
 	var err error
 	key := w.masterKey
 	for _, n := range path {
@@ -182,14 +199,22 @@ func (w *Wallet) derivePrivateKey(path accounts.DerivationPath) (*ecdsa.PrivateK
 			return nil, err
 		}
 	}
+	return key, nil
+}
 
-	privateKey, err := key.ECPrivKey()
-	privateKeyECDSA := privateKey.ToECDSA()
+// ExtendedPublicKey returns the xpub of the derivation path, usually the
+// account path m/44'/coin'/account', to derive its addresses without the seed.
+func (w *Wallet) ExtendedPublicKey(path accounts.DerivationPath) (string, error) {
+	key, err := w.deriveKey(path)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return privateKeyECDSA, nil
+	public, err := key.Neuter()
+	if err != nil {
+		return "", err
+	}
+	return public.String(), nil
 }
 
 // PrivateKeyHex return the ECDSA private key in hex string format of the account.
@@ -222,6 +247,17 @@ func (w *Wallet) PrivateKey(account accounts.Account) (*ecdsa.PrivateKey, error)
 	return w.derivePrivateKey(path)
 }
 
+// PrivateKeySecret returns the private key of the account as a redacted secret.
+func (w *Wallet) PrivateKeySecret(account accounts.Account) (secret.PrivateKey, error) {
+	privateKey, err := w.PrivateKey(account)
+	if err != nil {
+		return secret.PrivateKey{}, err
+	}
+	defer secret.ZeroECDSA(privateKey)
+
+	return secret.PrivateKeyFromECDSA(privateKey), nil
+}
+
 // ParseDerivationPath parses the derivation path in string format into []uint32
 func ParseDerivationPath(path string) (accounts.DerivationPath, error) {
 	return accounts.ParseDerivationPath(path)